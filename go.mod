@@ -6,5 +6,8 @@ require (
 	github.com/klauspost/compress v1.17.9
 	github.com/mappu/miqt v0.11.0
 	github.com/yusufpapurcu/wmi v1.2.3 // windows only
+	golang.org/x/crypto v0.40.0
+	golang.org/x/sys v0.34.0
 )
 
+require github.com/go-ole/go-ole v1.2.6 // indirect
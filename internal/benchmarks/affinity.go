@@ -0,0 +1,103 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"context"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// Placement pins test workers to CPUs: worker i runs on CPUs[i%len(CPUs)].
+// With no CPUs the OS scheduler places them. The same struct is recorded
+// in each Result, with Used and Err filled in from the run.
+type Placement struct {
+	Label string `json:"label"` // e.g. "os", "cpus", "physical cores", "node 0"
+	CPUs  []int  `json:"cpus,omitempty"`
+	Used  []int  `json:"used,omitempty"` // CPUs workers were actually pinned to
+	Err   string `json:"err,omitempty"`  // first pinning failure, if any
+}
+
+type pinKey struct{}
+
+type pinner struct {
+	cpus []int
+	mu   sync.Mutex
+	used map[int]bool
+	fail firstError
+}
+
+// RunPlaced runs a test with its workers pinned per p and records the
+// placement in the result. The calling goroutine is pinned as worker 0
+// too, since single-threaded tests do their work on it.
+func RunPlaced(ctx context.Context, p Placement, run func(context.Context) Result) Result {
+	if len(p.CPUs) == 0 {
+		r := run(ctx)
+		r.Placement = &Placement{Label: "os"}
+		return r
+	}
+	pn := &pinner{cpus: slices.Clone(p.CPUs), used: map[int]bool{}}
+	ctx = context.WithValue(ctx, pinKey{}, pn)
+	release := pinWorker(ctx, 0)
+	r := run(ctx)
+	release()
+
+	rec := Placement{Label: p.Label, CPUs: pn.cpus}
+	for cpu := range pn.used {
+		rec.Used = append(rec.Used, cpu)
+	}
+	slices.Sort(rec.Used)
+	if err := pn.fail.get(); err != nil {
+		rec.Err = err.Error()
+	}
+	r.Placement = &rec
+	return r
+}
+
+// pinWorker locks the calling goroutine to its OS thread and pins that
+// thread to worker i's CPU, if ctx carries a placement. The returned func
+// undoes both and must run before the goroutine exits.
+func pinWorker(ctx context.Context, i int) func() {
+	pn, _ := ctx.Value(pinKey{}).(*pinner)
+	if pn == nil {
+		return func() {}
+	}
+	cpu := pn.cpus[i%len(pn.cpus)]
+	runtime.LockOSThread()
+	restore, err := setAffinity(cpu)
+	if err != nil {
+		pn.fail.set(err)
+		runtime.UnlockOSThread()
+		return func() {}
+	}
+	pn.mu.Lock()
+	pn.used[cpu] = true
+	pn.mu.Unlock()
+	return func() {
+		if restore() != nil {
+			// stay locked: the thread then exits with the goroutine
+			// instead of going back to the pool with a narrowed mask
+			return
+		}
+		runtime.UnlockOSThread()
+	}
+}
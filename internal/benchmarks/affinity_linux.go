@@ -0,0 +1,44 @@
+//go:build linux
+
+// internal/benchmarks/affinity_linux.go
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// setAffinity pins the calling thread to cpu and returns a func that
+// restores its previous mask.
+func setAffinity(cpu int) (func() error, error) {
+	var old, set unix.CPUSet
+	if err := unix.SchedGetaffinity(0, &old); err != nil {
+		return nil, err
+	}
+	set.Set(cpu)
+	if err := unix.SchedSetaffinity(0, &set); err != nil {
+		return nil, fmt.Errorf("pin to CPU %d: %w", cpu, err)
+	}
+	return func() error { return unix.SchedSetaffinity(0, &old) }, nil
+}
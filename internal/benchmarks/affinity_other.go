@@ -0,0 +1,30 @@
+//go:build !linux
+
+// internal/benchmarks/affinity_other.go
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import "errors"
+
+func setAffinity(int) (func() error, error) {
+	return nil, errors.New("CPU affinity is only supported on Linux")
+}
@@ -21,7 +21,11 @@
 package benchmarks
 
 import (
+	"context"
 	"fmt"
+	"hash/crc32"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -29,30 +33,206 @@ type Result struct {
 	Name     string        `json:"name"`
 	Threads  int           `json:"threads"`
 	Duration time.Duration `json:"duration"`
-	Ops      uint64        `json:"ops"`
-	Bytes    uint64        `json:"bytes"`
-	Unit     string        `json:"unit"`
-	Err      string        `json:"err,omitempty"`
+	Ops      uint64        `json:"ops"`   // units of work done, e.g. hashes or FLOP
+	Bytes    uint64        `json:"bytes"` // bytes processed, for byte-rate tests
+	Status   Status        `json:"status"`
+	Err      string        `json:"err,omitempty"` // why the test did not pass
 	Notes    string        `json:"notes,omitempty"`
+	Checksum string        `json:"checksum,omitempty"` // verified digest of the work, if any
+	Corpus   string        `json:"corpus,omitempty"`   // input data profile, for compression tests
+	Latency  *Histogram    `json:"latency,omitempty"`  // per-operation latency, if recorded
+
+	// Throughput is the headline rate, computed once from the measured
+	// run time; the table, scores and exports all read it from here.
+	Throughput Throughput `json:"throughput"`
+
+	// Metrics holds named secondary measurements, e.g. per-operation rates.
+	Metrics map[string]float64 `json:"metrics,omitempty"`
+	// Series holds a measured curve for sweep-style tests.
+	Series *Series `json:"series,omitempty"`
+	// Matrices hold two-way tables, e.g. CPU node × memory node.
+	Matrices []*Matrix `json:"matrices,omitempty"`
+	// Energy is set by the runner when package energy can be measured.
+	Energy *Energy `json:"energy,omitempty"`
+	// Placement records where the workers ran; set by RunPlaced.
+	Placement *Placement `json:"placement,omitempty"`
+}
+
+// Series is a curve produced by a sweep, e.g. latency vs. working set size.
+type Series struct {
+	XLabel  string  `json:"x_label"`
+	YLabel  string  `json:"y_label"`
+	LogX    bool    `json:"log_x,omitempty"`
+	XBytes  bool    `json:"x_bytes,omitempty"` // X values are byte sizes
+	Scatter bool    `json:"scatter,omitempty"` // plot unconnected, labelled points
+	Points  []Point `json:"points"`
+}
+
+type Point struct {
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+	Label string  `json:"label,omitempty"`
+}
+
+// Matrix is a table of measurements indexed by two labels, e.g. the
+// bandwidth from each CPU node (rows) to each memory node (columns).
+type Matrix struct {
+	Title    string      `json:"title"`
+	RowLabel string      `json:"row_label"`
+	ColLabel string      `json:"col_label"`
+	Rows     []string    `json:"rows"`
+	Cols     []string    `json:"cols"`
+	Unit     string      `json:"unit"`
+	Values   [][]float64 `json:"values"` // Values[row][col]
+}
+
+func (r Result) ThroughputString() string { return r.Throughput.String() }
+
+// PercentileString formats the p-th latency percentile, or "—" when the
+// test does not record latency.
+func (r Result) PercentileString(p float64) string {
+	if r.Latency == nil || r.Latency.Count() == 0 {
+		return "—"
+	}
+	return HumanDuration(r.Latency.Percentile(p))
+}
+
+// HumanDuration formats a latency in the largest unit below it, as the
+// result tables and the histogram view show it.
+func HumanDuration(d time.Duration) string {
+	switch {
+	case d < time.Microsecond:
+		return fmt.Sprintf("%d ns", d.Nanoseconds())
+	case d < time.Millisecond:
+		return fmt.Sprintf("%.2f µs", float64(d)/1e3)
+	case d < time.Second:
+		return fmt.Sprintf("%.2f ms", float64(d)/1e6)
+	}
+	return fmt.Sprintf("%.2f s", d.Seconds())
+}
+
+// verifyEvery is how often (in iterations) a worker checks its output
+// against the reference. Comparing or hashing a whole output costs a fair
+// share of producing it, so every test samples at this one rate; the
+// first iteration is always checked.
+const verifyEvery = 8
+
+// verifyIteration reports whether iteration n (from 1) is checked.
+func verifyIteration(n int) bool { return n == 1 || n%verifyEvery == 0 }
+
+// verifyErr formats a failed output check for Result.Err.
+func verifyErr(what string, got, want any) error {
+	return fmt.Errorf("verification failed: %s = %v, want %v", what, got, want)
 }
 
-func (r Result) ThroughputString() string {
-	if r.Unit == "B/s" {
-		bps := uint64(0)
-		if r.Duration > 0 {
-			bps = uint64(float64(r.Bytes) / r.Duration.Seconds())
-		}
-		return humanBytes(bps) + "/s"
+// firstError keeps the first error reported by any worker.
+type firstError struct {
+	mu  sync.Mutex
+	err error
+}
+
+func (f *firstError) set(err error) {
+	f.mu.Lock()
+	if f.err == nil {
+		f.err = err
 	}
-	if r.Unit == "GFLOP/s" {
-		return fmt.Sprintf("%.2f %s", float64(r.Ops)/1e6, r.Unit)
+	f.mu.Unlock()
+}
+
+func (f *firstError) get() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.err
+}
+
+// workFn runs one verified iteration and returns the units of work it
+// did (items, edges, bytes...).
+type workFn func() (uint64, error)
+
+// runWorkers runs a workFn per thread until dur elapses and returns the
+// summed work, the wall time and the per-iteration latencies. newWorker
+// gives each worker its own scratch state; every worker builds it before
+// the clock and the deadline start. The first error stops all workers.
+func runWorkers(ctx context.Context, dur time.Duration, threads int, newWorker func() workFn) (uint64, time.Duration, *Histogram, error) {
+	var wg, ready sync.WaitGroup
+	var mu sync.Mutex
+	var total uint64
+	var fail firstError
+	lat := NewHistogram()
+	var run context.Context
+	var cancel context.CancelFunc
+	begin := make(chan struct{})
+	for i := 0; i < threads; i++ {
+		wg.Add(1)
+		ready.Add(1)
+		go func() {
+			defer wg.Done()
+			defer pinWorker(ctx, i)()
+			fn := newWorker()
+			h := NewHistogram()
+			var local uint64
+			ready.Done()
+			<-begin
+			for {
+				select {
+				case <-run.Done():
+					mu.Lock()
+					total += local
+					lat.Merge(h)
+					mu.Unlock()
+					return
+				default:
+					t0 := time.Now()
+					n, err := fn()
+					h.Record(time.Since(t0))
+					if err != nil {
+						fail.set(err)
+						cancel()
+					}
+					local += n
+				}
+			}
+		}()
 	}
-	if r.Ops > 0 {
-		return fmt.Sprintf("%d %s", r.Ops/uint64(max64(1, int64(r.Duration.Seconds()))), r.Unit)
+	ready.Wait()
+	run, cancel = context.WithTimeout(ctx, dur)
+	defer cancel()
+	start := time.Now()
+	close(begin)
+	wg.Wait()
+	return total, time.Since(start), lat, fail.get()
+}
+
+// parallelFor splits [0,n) into chunks handed out to threads workers as
+// they become free, so uneven chunks (Mandelbrot rows) still balance.
+func parallelFor(ctx context.Context, threads, n, chunk int, fn func(lo, hi int)) {
+	if threads <= 1 {
+		fn(0, n)
+		return
 	}
-	return "—"
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for t := 0; t < threads; t++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer pinWorker(ctx, t)()
+			for {
+				lo := int(next.Add(int64(chunk))) - chunk
+				if lo >= n {
+					return
+				}
+				fn(lo, min(lo+chunk, n))
+			}
+		}()
+	}
+	wg.Wait()
 }
 
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+func crcHex(v uint32) string { return fmt.Sprintf("crc32c:%08x", v) }
+
 func humanBytes(b uint64) string {
 	suffix := []string{"B", "KB", "MB", "GB", "TB"}
 	f := float64(b)
@@ -63,10 +243,3 @@ func humanBytes(b uint64) string {
 	}
 	return fmt.Sprintf("%.2f %s", f, suffix[i])
 }
-
-func max64(a, b int64) int64 {
-	if a > b {
-		return a
-	}
-	return b
-}
@@ -0,0 +1,182 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+)
+
+// corpusVersion changes whenever the generators below change, so exported
+// numbers are only compared between runs over identical bytes.
+const corpusVersion = 1
+
+const corpusBlock = 64 * 1024
+
+type CorpusSegment struct {
+	Kind  string  // text, json, binary or random
+	Share float64 // fraction of the corpus
+}
+
+// CorpusProfile is a named, deterministic mix of generated data used by all
+// compression tests.
+type CorpusProfile struct {
+	Name     string
+	Segments []CorpusSegment
+}
+
+var CorpusProfiles = []CorpusProfile{
+	{Name: "mixed", Segments: []CorpusSegment{{"text", 0.4}, {"json", 0.3}, {"binary", 0.2}, {"random", 0.1}}},
+	{Name: "text", Segments: []CorpusSegment{{"text", 1}}},
+	{Name: "json", Segments: []CorpusSegment{{"json", 1}}},
+	{Name: "binary", Segments: []CorpusSegment{{"binary", 0.8}, {"random", 0.2}}},
+}
+
+// LookupCorpus returns the named profile, falling back to "mixed".
+func LookupCorpus(name string) CorpusProfile {
+	for _, p := range CorpusProfiles {
+		if p.Name == name {
+			return p
+		}
+	}
+	return CorpusProfiles[0]
+}
+
+// String describes the profile for Result.Corpus, e.g.
+// "mixed/v1: text 40%, json 30%, binary 20%, random 10%".
+func (p CorpusProfile) String() string {
+	parts := make([]string, len(p.Segments))
+	for i, s := range p.Segments {
+		parts[i] = fmt.Sprintf("%s %.0f%%", s.Kind, s.Share*100)
+	}
+	return fmt.Sprintf("%s/v%d: %s", p.Name, corpusVersion, strings.Join(parts, ", "))
+}
+
+// Generate returns size bytes of the profile. Segments are interleaved in
+// 64 KiB blocks by smooth weighted round-robin, and each segment has its
+// own generator seeded by its index in the profile, so the output depends
+// only on profile and size.
+func (p CorpusProfile) Generate(size int) []byte {
+	gens := make([]func([]byte), len(p.Segments))
+	for i, s := range p.Segments {
+		gens[i] = corpusGenerator(s.Kind, int64(1000+i))
+	}
+	out := make([]byte, size)
+	credit := make([]float64, len(p.Segments))
+	for off := 0; off < size; off += corpusBlock {
+		best := 0
+		for i, s := range p.Segments {
+			credit[i] += s.Share
+			if credit[i] > credit[best] {
+				best = i
+			}
+		}
+		credit[best]--
+		gens[best](out[off:min(off+corpusBlock, size)])
+	}
+	return out
+}
+
+func corpusGenerator(kind string, seed int64) func([]byte) {
+	r := rand.New(rand.NewSource(seed))
+	switch kind {
+	case "text":
+		zipf := rand.NewZipf(r, 1.1, 2, uint64(len(corpusWords)-1))
+		return func(b []byte) { fillText(b, r, zipf) }
+	case "json":
+		var seq int
+		return func(b []byte) { fillJSON(b, r, &seq) }
+	case "binary":
+		var rec uint32
+		return func(b []byte) { fillBinary(b, r, &rec) }
+	}
+	return func(b []byte) { r.Read(b) }
+}
+
+var corpusWords = strings.Fields(`the of and to in a is that for it as was with be by on not he
+this are or his from at which but have an they you were her she there would their we him been
+has when who will more no if out so said what up its about into than them can only other new
+some could time these two may then do first any my now such like our over man me even most made
+after also did many before must through back years where much your way well down should because
+each just those people how too little state good very make world still own see men work long get
+here between both life being under never day same another know while last might us great old year
+off come since against go came right used take three server request response error cache latency
+database query index thread memory disk network client user session token build deploy release`)
+
+func fillText(b []byte, r *rand.Rand, zipf *rand.Zipf) {
+	var sb strings.Builder
+	sb.Grow(len(b) + 64)
+	sentence := 0
+	for sb.Len() < len(b) {
+		w := corpusWords[zipf.Uint64()]
+		if sentence == 0 {
+			w = strings.ToUpper(w[:1]) + w[1:]
+		}
+		sb.WriteString(w)
+		sentence++
+		switch {
+		case sentence > 6 && r.Intn(8) == 0:
+			sb.WriteString(". ")
+			sentence = 0
+			if r.Intn(6) == 0 {
+				sb.WriteString("\n\n")
+			}
+		case r.Intn(12) == 0:
+			sb.WriteString(", ")
+		default:
+			sb.WriteByte(' ')
+		}
+	}
+	copy(b, sb.String())
+}
+
+func fillJSON(b []byte, r *rand.Rand, seq *int) {
+	levels := []string{"debug", "info", "info", "info", "warn", "error"}
+	paths := []string{"/api/v1/users", "/api/v1/orders", "/api/v2/search", "/healthz", "/static/app.js"}
+	var sb strings.Builder
+	sb.Grow(len(b) + 256)
+	for sb.Len() < len(b) {
+		*seq++
+		fmt.Fprintf(&sb, `{"ts":%d,"level":%q,"path":%q,"status":%d,"user_id":%d,"latency_ms":%.3f,"msg":"%s %s"}`+"\n",
+			1700000000000+int64(*seq)*37, levels[r.Intn(len(levels))], paths[r.Intn(len(paths))],
+			[]int{200, 200, 200, 201, 304, 404, 500}[r.Intn(7)], r.Intn(50000), r.ExpFloat64()*20,
+			corpusWords[r.Intn(len(corpusWords))], corpusWords[r.Intn(len(corpusWords))])
+	}
+	copy(b, sb.String())
+}
+
+// fillBinary writes fixed-size little-endian records resembling a table
+// dump: a counter, a small enum, a slowly drifting float and padding.
+func fillBinary(b []byte, r *rand.Rand, rec *uint32) {
+	var buf [32]byte
+	for off := 0; off < len(b); off += len(buf) {
+		*rec++
+		binary.LittleEndian.PutUint32(buf[0:], *rec)
+		binary.LittleEndian.PutUint16(buf[4:], uint16(r.Intn(16)))
+		binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(float32(*rec)*0.25+float32(r.NormFloat64())))
+		binary.LittleEndian.PutUint64(buf[12:], uint64(r.Int63n(1<<20)))
+		clear(buf[20:])
+		copy(b[off:], buf[:])
+	}
+}
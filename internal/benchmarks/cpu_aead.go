@@ -0,0 +1,113 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"hash/crc32"
+	"runtime"
+	"time"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// AEADAlg selects the cipher used by RunCPUAEAD.
+type AEADAlg int
+
+const (
+	AESGCM AEADAlg = iota
+	ChaCha20Poly1305
+)
+
+func (a AEADAlg) String() string {
+	if a == ChaCha20Poly1305 {
+		return "ChaCha20-Poly1305"
+	}
+	return "AES-GCM"
+}
+
+func (a AEADAlg) new(key []byte) (cipher.AEAD, error) {
+	if a == ChaCha20Poly1305 {
+		return chacha20poly1305.New(key)
+	}
+	blk, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(blk)
+}
+
+// RunCPUAEAD measures sealing throughput over bufSize-byte messages with
+// a 256-bit key. The nonce is fixed so every message has the same
+// ciphertext; that is fine for a benchmark and nothing else.
+func RunCPUAEAD(ctx context.Context, dur time.Duration, threads int, alg AEADAlg, bufSize int) Result {
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	if bufSize < 64 {
+		bufSize = DefaultCryptoBuf
+	}
+	name := alg.String()
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(i*29 + 1)
+	}
+	aead, err := alg.new(key)
+	if err != nil {
+		return Result{Name: name, Threads: threads, Err: err.Error()}
+	}
+	nonce := make([]byte, aead.NonceSize())
+	for i := range nonce {
+		nonce[i] = byte(i * 7)
+	}
+	ad := []byte("benchy")
+	src := newCryptoBuf(bufSize)
+	want := aead.Seal(nil, nonce, src, ad)
+	if pt, err := aead.Open(nil, nonce, want, ad); err != nil {
+		return Result{Name: name, Threads: threads, Err: err.Error()}
+	} else if !bytes.Equal(pt, src) {
+		return Result{Name: name, Threads: threads, Err: name + ": reference round trip mismatch"}
+	}
+
+	msgs := cryptoMsgs(bufSize)
+	total, elapsed, _, err := runWorkers(ctx, dur, threads, func() workFn {
+		dst := make([]byte, 0, len(want))
+		n := 0
+		return func() (uint64, error) {
+			for range msgs {
+				dst = aead.Seal(dst[:0], nonce, src, ad)
+			}
+			n++
+			if verifyIteration(n) && !bytes.Equal(dst, want) {
+				return 0, verifyErr(name+" ciphertext crc32c", crcHex(crc32.Checksum(dst, castagnoli)), crcHex(crc32.Checksum(want, castagnoli)))
+			}
+			return uint64(msgs * bufSize), nil
+		}
+	})
+	if err != nil {
+		return Result{Name: name, Threads: threads, Err: err.Error()}
+	}
+	return Result{Name: name, Threads: threads, Duration: elapsed, Bytes: total, Throughput: perSecond(total, elapsed, BytesPerSec),
+		Checksum: crcHex(crc32.Checksum(want, castagnoli)), Notes: "key=256-bit, buf=" + BufLabel(bufSize)}
+}
@@ -21,55 +21,67 @@
 package benchmarks
 
 import (
+	"bytes"
 	"context"
 	"crypto/aes"
 	"crypto/cipher"
-	"crypto/rand"
 	"fmt"
+	"hash/crc32"
 	"runtime"
-	"sync"
 	"time"
 )
 
-func RunCPUAES(ctx context.Context, dur time.Duration, threads, keyLen int) Result {
+// RunCPUAES encrypts blockSize-byte messages, each with a fresh CTR
+// stream; blockSize 0 means 8 MB, where per-call overhead vanishes.
+func RunCPUAES(ctx context.Context, dur time.Duration, threads, keyLen, blockSize int) Result {
 	if threads <= 0 {
 		threads = runtime.NumCPU()
 	}
 	if keyLen != 16 && keyLen != 24 && keyLen != 32 {
 		keyLen = 32
 	}
-	ctx, cancel := context.WithTimeout(ctx, dur)
-	defer cancel()
+	if blockSize <= 0 {
+		blockSize = 8 * 1024 * 1024
+	}
 
-	var wg sync.WaitGroup
-	var bytes uint64
-	blockSize := 8 * 1024 * 1024
+	// fixed key, IV and plaintext so every block has the same ciphertext
+	key := make([]byte, keyLen)
+	iv := make([]byte, aes.BlockSize)
+	for i := range key {
+		key[i] = byte(i*29 + 1)
+	}
+	for i := range iv {
+		iv[i] = byte(i * 7)
+	}
+	src := make([]byte, blockSize)
+	for i := range src {
+		src[i] = byte(i ^ i>>8)
+	}
+	blk, err := aes.NewCipher(key)
+	if err != nil {
+		return Result{Name: "AES-CTR", Threads: threads, Err: err.Error()}
+	}
+	want := make([]byte, blockSize)
+	cipher.NewCTR(blk, iv).XORKeyStream(want, src)
 
-	for i := 0; i < threads; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			key := make([]byte, keyLen)
-			_, _ = rand.Read(key)
-			blk, _ := aes.NewCipher(key)
-			iv := make([]byte, aes.BlockSize)
-			_, _ = rand.Read(iv)
-			stream := cipher.NewCTR(blk, iv)
-			src := make([]byte, blockSize)
-			dst := make([]byte, blockSize)
-			var local uint64
-			for {
-				select {
-				case <-ctx.Done():
-					bytes += local
-					return
-				default:
-					stream.XORKeyStream(dst, src)
-					local += uint64(blockSize)
-				}
+	msgs := cryptoMsgs(blockSize)
+	total, elapsed, _, err := runWorkers(ctx, dur, threads, func() workFn {
+		dst := make([]byte, blockSize)
+		n := 0
+		return func() (uint64, error) {
+			for range msgs {
+				cipher.NewCTR(blk, iv).XORKeyStream(dst, src)
+			}
+			n++
+			if verifyIteration(n) && !bytes.Equal(dst, want) {
+				return 0, verifyErr("aes-ctr ciphertext crc32c", crcHex(crc32.Checksum(dst, castagnoli)), crcHex(crc32.Checksum(want, castagnoli)))
 			}
-		}()
+			return uint64(msgs * blockSize), nil
+		}
+	})
+	if err != nil {
+		return Result{Name: "AES-CTR", Threads: threads, Err: err.Error()}
 	}
-	wg.Wait()
-	return Result{Name: "AES-CTR", Threads: threads, Duration: dur, Bytes: bytes, Unit: "B/s", Notes: "key=" + fmt.Sprintf("%d-bit", keyLen*8)}
+	return Result{Name: "AES-CTR", Threads: threads, Duration: elapsed, Bytes: total, Throughput: perSecond(total, elapsed, BytesPerSec),
+		Checksum: crcHex(crc32.Checksum(want, castagnoli)), Notes: fmt.Sprintf("key=%d-bit, buf=%s", keyLen*8, BufLabel(blockSize))}
 }
@@ -0,0 +1,92 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Levels visited by the sweep tests. The zstd encoder only has four
+// distinct speed settings, so one representative level each.
+var (
+	ZstdSweepLevels = []int{1, 3, 7, 11}
+	GzipSweepLevels = []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+)
+
+func RunCPUZstdSweep(ctx context.Context, dur time.Duration, threads int, corpus string) Result {
+	return compressSweep(ctx, dur, "Zstd Level Sweep", ZstdSweepLevels, func(ctx context.Context, d time.Duration, level int) Result {
+		return RunCPUZstd(ctx, d, threads, level, corpus)
+	})
+}
+
+func RunCPUGzipSweep(ctx context.Context, dur time.Duration, threads int, corpus string) Result {
+	return compressSweep(ctx, dur, "Gzip Level Sweep", GzipSweepLevels, func(ctx context.Context, d time.Duration, level int) Result {
+		return RunCPUGzip(ctx, d, threads, level, corpus)
+	})
+}
+
+// compressSweep splits dur across levels and collects compression speed
+// against ratio as a scatter series, one labelled point per level.
+func compressSweep(ctx context.Context, dur time.Duration, name string, levels []int, run func(context.Context, time.Duration, int) Result) Result {
+	per := max(dur/time.Duration(len(levels)), 500*time.Millisecond)
+	series := &Series{XLabel: "MiB/s", YLabel: "ratio", Scatter: true}
+	metrics := map[string]float64{}
+	var sums []string
+	var total Result
+	var fastest, densest Point
+
+	for _, level := range levels {
+		if ctx.Err() != nil {
+			return Result{Name: name, Threads: total.Threads, Err: "canceled", Series: series}
+		}
+		r := run(ctx, per, level)
+		if r.Err != "" {
+			return Result{Name: name, Threads: r.Threads, Err: fmt.Sprintf("level %d: %s", level, r.Err), Series: series}
+		}
+		speed := r.Throughput.Value / (1 << 20)
+		pt := Point{X: speed, Y: r.Metrics["ratio"], Label: fmt.Sprintf("L%d", level)}
+		series.Points = append(series.Points, pt)
+		metrics[fmt.Sprintf("level%d_mib_per_s", level)] = speed
+		metrics[fmt.Sprintf("level%d_ratio", level)] = pt.Y
+		sums = append(sums, fmt.Sprintf("L%d=%s", level, r.Checksum))
+		if pt.X > fastest.X {
+			fastest = pt
+		}
+		if pt.Y > densest.Y {
+			densest = pt
+		}
+		total.Threads = r.Threads
+		total.Bytes += r.Bytes
+		total.Duration += r.Duration
+		total.Corpus = r.Corpus
+	}
+
+	total.Name = name
+	total.Throughput = perSecond(total.Bytes, total.Duration, BytesPerSec)
+	total.Series = series
+	total.Metrics = metrics
+	total.Checksum = strings.Join(sums, " ")
+	total.Notes = fmt.Sprintf("fastest %s %.0f MiB/s, best ratio %s %.2f", fastest.Label, fastest.X, densest.Label, densest.Y)
+	return total
+}
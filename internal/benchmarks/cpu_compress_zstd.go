@@ -23,45 +23,58 @@ package benchmarks
 import (
 	"context"
 	"fmt"
+	"hash/crc32"
 	"runtime"
-	"sync"
 	"time"
 
 	"github.com/klauspost/compress/zstd"
 )
 
-func RunCPUZstd(ctx context.Context, dur time.Duration, threads, level int) Result {
+// compressBlockSize is the input size of every compression test.
+const compressBlockSize = 4 * 1024 * 1024
+
+func RunCPUZstd(ctx context.Context, dur time.Duration, threads, level int, corpus string) Result {
 	if threads <= 0 {
 		threads = runtime.NumCPU()
 	}
 	if level < 1 || level > 19 {
 		level = 3
 	}
-	ctx, cancel := context.WithTimeout(ctx, dur)
-	defer cancel()
+	prof := LookupCorpus(corpus)
+	block := prof.Generate(compressBlockSize)
 
-	var wg sync.WaitGroup
-	var bytes uint64
-	block := make([]byte, 4*1024*1024)
+	newEnc := func() (*zstd.Encoder, error) {
+		return zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+	}
+	ref, err := newEnc()
+	if err != nil {
+		return Result{Name: "Zstd Compress", Threads: threads, Err: err.Error()}
+	}
+	refOut := ref.EncodeAll(block, nil)
+	want := crc32.Checksum(refOut, castagnoli)
 
-	for i := 0; i < threads; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			enc, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
-			var local uint64
-			for {
-				select {
-				case <-ctx.Done():
-					bytes += local
-					return
-				default:
-					_ = enc.EncodeAll(block, nil)
-					local += uint64(len(block))
+	total, elapsed, lat, err := runWorkers(ctx, dur, threads, func() workFn {
+		enc, err := newEnc()
+		var out []byte
+		n := 0
+		return func() (uint64, error) {
+			if err != nil {
+				return 0, err
+			}
+			out = enc.EncodeAll(block, out[:0])
+			n++
+			if verifyIteration(n) {
+				if got := crc32.Checksum(out, castagnoli); got != want {
+					return 0, verifyErr("zstd output crc32c", crcHex(got), crcHex(want))
 				}
 			}
-		}()
+			return uint64(len(block)), nil
+		}
+	})
+	if err != nil {
+		return Result{Name: "Zstd Compress", Threads: threads, Err: err.Error()}
 	}
-	wg.Wait()
-	return Result{Name: "Zstd Compress", Threads: threads, Duration: dur, Bytes: bytes, Unit: "B/s", Notes: "level=" + fmt.Sprint(level)}
+	return Result{Name: "Zstd Compress", Threads: threads, Duration: elapsed, Bytes: total, Throughput: perSecond(total, elapsed, BytesPerSec), Latency: lat,
+		Checksum: crcHex(want), Corpus: prof.String(), Metrics: map[string]float64{"ratio": ratio(block, refOut)},
+		Notes: fmt.Sprintf("level=%d, %s", level, compressionRatio(block, refOut))}
 }
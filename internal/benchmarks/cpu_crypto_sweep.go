@@ -0,0 +1,86 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CryptoSweepSizes are the message sizes visited by the size sweeps,
+// from the handshake-sized records where per-call overhead dominates
+// up to bulk transfers.
+var CryptoSweepSizes = []int{64, 256, 1 << 10, 4 << 10, 16 << 10, 64 << 10}
+
+func RunHashSizeSweep(ctx context.Context, dur time.Duration, threads int, alg HashAlg) Result {
+	return sizeSweep(ctx, dur, alg.String()+" Size Sweep", func(ctx context.Context, d time.Duration, size int) Result {
+		return RunCPUHash(ctx, d, threads, alg, size)
+	})
+}
+
+func RunAEADSizeSweep(ctx context.Context, dur time.Duration, threads int, alg AEADAlg) Result {
+	return sizeSweep(ctx, dur, alg.String()+" Size Sweep", func(ctx context.Context, d time.Duration, size int) Result {
+		return RunCPUAEAD(ctx, d, threads, alg, size)
+	})
+}
+
+func RunAESSizeSweep(ctx context.Context, dur time.Duration, threads int) Result {
+	return sizeSweep(ctx, dur, "AES-CTR Size Sweep", func(ctx context.Context, d time.Duration, size int) Result {
+		return RunCPUAES(ctx, d, threads, 32, size)
+	})
+}
+
+// sizeSweep splits dur across CryptoSweepSizes and collects throughput
+// per message size, the way openssl speed prints one row per algorithm.
+// The reported throughput is the overall average; the curve is in Series.
+func sizeSweep(ctx context.Context, dur time.Duration, name string, run func(context.Context, time.Duration, int) Result) Result {
+	per := max(dur/time.Duration(len(CryptoSweepSizes)), 300*time.Millisecond)
+	series := &Series{XLabel: "Message size", YLabel: "MiB/s", LogX: true, XBytes: true}
+	metrics := map[string]float64{}
+	var cols []string
+	var total Result
+
+	for _, size := range CryptoSweepSizes {
+		if ctx.Err() != nil {
+			return Result{Name: name, Threads: total.Threads, Err: "canceled", Series: series}
+		}
+		r := run(ctx, per, size)
+		if r.Err != "" {
+			return Result{Name: name, Threads: r.Threads, Err: fmt.Sprintf("%s: %s", BufLabel(size), r.Err), Series: series}
+		}
+		speed := r.Throughput.Value / (1 << 20)
+		series.Points = append(series.Points, Point{X: float64(size), Y: speed})
+		metrics[fmt.Sprintf("buf%d_mib_per_s", size)] = speed
+		cols = append(cols, fmt.Sprintf("%s %.0f", BufLabel(size), speed))
+		total.Threads = r.Threads
+		total.Bytes += r.Bytes
+		total.Duration += r.Duration
+	}
+
+	total.Name = name
+	total.Throughput = perSecond(total.Bytes, total.Duration, BytesPerSec)
+	total.Series = series
+	total.Metrics = metrics
+	total.Notes = "MiB/s: " + strings.Join(cols, ", ")
+	return total
+}
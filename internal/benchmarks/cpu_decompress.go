@@ -0,0 +1,164 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"runtime"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// decompressFn decodes comp into a buffer owned by the worker and returns
+// the decoded bytes.
+type decompressFn func(comp []byte) ([]byte, error)
+
+// runDecompress times decode over a pre-compressed block on every worker;
+// newWorker returns each worker's decoder. Throughput is bytes of
+// decompressed output.
+func runDecompress(ctx context.Context, dur time.Duration, threads int, name string, src, comp []byte, newWorker func() (decompressFn, error)) Result {
+	want := crc32.Checksum(src, castagnoli)
+	total, elapsed, lat, err := runWorkers(ctx, dur, threads, func() workFn {
+		dec, err := newWorker()
+		n := 0
+		return func() (uint64, error) {
+			if err != nil {
+				return 0, err
+			}
+			out, err := dec(comp)
+			if err != nil {
+				return 0, err
+			}
+			n++
+			if verifyIteration(n) && !bytes.Equal(out, src) {
+				return 0, verifyErr("round-trip crc32c", crcHex(crc32.Checksum(out, castagnoli)), crcHex(want))
+			}
+			return uint64(len(out)), nil
+		}
+	})
+	if err != nil {
+		return Result{Name: name, Threads: threads, Err: err.Error()}
+	}
+	return Result{Name: name, Threads: threads, Duration: elapsed, Bytes: total, Throughput: perSecond(total, elapsed, BytesPerSec), Latency: lat,
+		Checksum: crcHex(want)}
+}
+
+func ratio(src, comp []byte) float64 {
+	return float64(len(src)) / float64(max(1, len(comp)))
+}
+
+func compressionRatio(src, comp []byte) string {
+	return fmt.Sprintf("ratio=%.2f", ratio(src, comp))
+}
+
+// RunCPUZstdDecompress compresses the corpus once at the given level, then
+// times zstd.Decoder.DecodeAll over it.
+func RunCPUZstdDecompress(ctx context.Context, dur time.Duration, threads, level int, corpus string) Result {
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	if level < 1 || level > 19 {
+		level = 3
+	}
+	const name = "Zstd Decompress"
+	prof := LookupCorpus(corpus)
+	src := prof.Generate(compressBlockSize)
+	enc, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+	if err != nil {
+		return Result{Name: name, Threads: threads, Err: err.Error()}
+	}
+	comp := enc.EncodeAll(src, nil)
+
+	res := runDecompress(ctx, dur, threads, name, src, comp, func() (decompressFn, error) {
+		d, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		out := make([]byte, 0, len(src))
+		return func(comp []byte) ([]byte, error) {
+			var err error
+			out, err = d.DecodeAll(comp, out[:0])
+			return out, err
+		}, nil
+	})
+	res.Corpus = prof.String()
+	res.Notes = fmt.Sprintf("level=%d, %s", level, compressionRatio(src, comp))
+	return res
+}
+
+// RunCPUGzipDecompress compresses the corpus once at the given level, then
+// times a reused gzip.Reader over it.
+func RunCPUGzipDecompress(ctx context.Context, dur time.Duration, threads, level int, corpus string) Result {
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	if level < gzip.HuffmanOnly || level > gzip.BestCompression {
+		level = gzip.DefaultCompression
+	}
+	const name = "Gzip Decompress"
+	prof := LookupCorpus(corpus)
+	src := prof.Generate(compressBlockSize)
+	var comp bytes.Buffer
+	if err := gzipOnce(&comp, src, level); err != nil {
+		return Result{Name: name, Threads: threads, Err: err.Error()}
+	}
+
+	res := runDecompress(ctx, dur, threads, name, src, comp.Bytes(), func() (decompressFn, error) {
+		var zr *gzip.Reader
+		var rd bytes.Reader
+		out := make([]byte, len(src))
+		var tail [1]byte
+		return func(comp []byte) ([]byte, error) {
+			rd.Reset(comp)
+			if zr == nil {
+				var err error
+				if zr, err = gzip.NewReader(&rd); err != nil {
+					return nil, err
+				}
+			} else if err := zr.Reset(&rd); err != nil {
+				return nil, err
+			}
+			n, err := io.ReadFull(zr, out)
+			if err != nil && err != io.ErrUnexpectedEOF {
+				return nil, err
+			}
+			// the reader checks the CRC-32 and size trailer only when it
+			// reaches the end, which filling out alone never does
+			if _, err := zr.Read(tail[:]); err != io.EOF {
+				if err == nil {
+					err = errors.New("gzip: more data than the input")
+				}
+				return nil, err
+			}
+			return out[:n], nil
+		}, nil
+	})
+	res.Corpus = prof.String()
+	res.Notes = fmt.Sprintf("level=%d, %s", level, compressionRatio(src, comp.Bytes()))
+	return res
+}
@@ -0,0 +1,178 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"time"
+)
+
+// graph is a directed graph in CSR form: the edges of node u are
+// to[off[u]:off[u+1]] with weights w[off[u]:off[u+1]].
+type graph struct {
+	off []int32
+	to  []int32
+	w   []uint32
+}
+
+// genGraph links every node to its successor (so everything is
+// reachable from 0) plus degree-1 random nodes, weights 1..100.
+func genGraph(n, degree int) *graph {
+	r := rand.New(rand.NewSource(11))
+	g := &graph{off: make([]int32, n+1)}
+	for u := 0; u < n; u++ {
+		g.off[u] = int32(len(g.to))
+		g.to = append(g.to, int32((u+1)%n))
+		g.w = append(g.w, uint32(1+r.Intn(100)))
+		for k := 1; k < degree; k++ {
+			g.to = append(g.to, int32(r.Intn(n)))
+			g.w = append(g.w, uint32(1+r.Intn(100)))
+		}
+	}
+	g.off[n] = int32(len(g.to))
+	return g
+}
+
+type pqItem struct {
+	node int32
+	dist uint64
+}
+
+type distHeap []pqItem
+
+func (h distHeap) Len() int           { return len(h) }
+func (h distHeap) Less(i, j int) bool { return h[i].dist < h[j].dist }
+func (h distHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *distHeap) Push(x any)        { *h = append(*h, x.(pqItem)) }
+func (h *distHeap) Pop() any {
+	old := *h
+	it := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return it
+}
+
+// shortestPaths runs Dijkstra from node 0 into dist, reusing pq, and
+// returns the number of edges relaxed.
+func (g *graph) shortestPaths(dist []uint64, pq *distHeap) uint64 {
+	for i := range dist {
+		dist[i] = math.MaxUint64
+	}
+	*pq = (*pq)[:0]
+	dist[0] = 0
+	heap.Push(pq, pqItem{0, 0})
+	var relaxed uint64
+	for pq.Len() > 0 {
+		it := heap.Pop(pq).(pqItem)
+		if it.dist > dist[it.node] {
+			continue // stale entry
+		}
+		for e := g.off[it.node]; e < g.off[it.node+1]; e++ {
+			relaxed++
+			v, nd := g.to[e], it.dist+uint64(g.w[e])
+			if nd < dist[v] {
+				dist[v] = nd
+				heap.Push(pq, pqItem{v, nd})
+			}
+		}
+	}
+	return relaxed
+}
+
+// certify checks dist is the shortest-path solution without trusting
+// the code that produced it: no edge can still be relaxed, and every
+// node but the source is reached through some tight edge.
+func (g *graph) certify(dist []uint64) error {
+	tight := make([]bool, len(dist))
+	tight[0] = dist[0] == 0
+	for u := range dist {
+		for e := g.off[u]; e < g.off[u+1]; e++ {
+			v, nd := g.to[e], dist[u]+uint64(g.w[e])
+			if nd < dist[v] {
+				return fmt.Errorf("edge %d->%d still relaxes", u, v)
+			}
+			if nd == dist[v] {
+				tight[v] = true
+			}
+		}
+	}
+	for v, ok := range tight {
+		if !ok {
+			return fmt.Errorf("node %d has no tight in-edge", v)
+		}
+	}
+	return nil
+}
+
+func distChecksum(dist []uint64) uint64 {
+	h := newFNV()
+	for _, d := range dist {
+		h.mix(d)
+	}
+	return uint64(h)
+}
+
+// GraphNodes and GraphDegree size the graph RunCPUDijkstra searches.
+const (
+	GraphNodes  = 1 << 16
+	GraphDegree = 4
+)
+
+// RunCPUDijkstra runs single-source shortest paths with a binary heap
+// over a generated sparse graph, counting relaxed edges.
+func RunCPUDijkstra(ctx context.Context, dur time.Duration, threads int) Result {
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	g := genGraph(GraphNodes, GraphDegree)
+	ref := make([]uint64, GraphNodes)
+	var pq distHeap
+	g.shortestPaths(ref, &pq)
+	if err := g.certify(ref); err != nil {
+		return Result{Name: "Dijkstra", Threads: threads, Err: "reference: " + err.Error()}
+	}
+	want := distChecksum(ref)
+
+	edges, elapsed, lat, err := runWorkers(ctx, dur, threads, func() workFn {
+		dist := make([]uint64, GraphNodes)
+		var pq distHeap
+		iter := 0
+		return func() (uint64, error) {
+			n := g.shortestPaths(dist, &pq)
+			iter++
+			if verifyIteration(iter) {
+				if got := distChecksum(dist); got != want {
+					return 0, verifyErr("distance checksum", fmt.Sprintf("%016x", got), fmt.Sprintf("%016x", want))
+				}
+			}
+			return n, nil
+		}
+	})
+	if err != nil {
+		return Result{Name: "Dijkstra", Threads: threads, Err: err.Error()}
+	}
+	return Result{Name: "Dijkstra", Threads: threads, Duration: elapsed, Ops: edges, Throughput: perSecond(edges, elapsed, EdgesPerSec), Latency: lat,
+		Checksum: fmt.Sprintf("fnv64:%016x", want), Notes: fmt.Sprintf("%d nodes, %d edges", GraphNodes, len(g.to))}
+}
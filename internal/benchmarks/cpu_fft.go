@@ -0,0 +1,164 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/bits"
+	"math/cmplx"
+	"runtime"
+	"time"
+)
+
+// FFTSize is the transform length; 2^16 complex128 values is 1 MB, so
+// the working set sits in L2/L3 rather than L1.
+const FFTSize = 1 << 16
+
+// fftPlan holds the twiddle factors and bit-reversal table for one size.
+type fftPlan struct {
+	n       int
+	twiddle []complex128
+	rev     []int
+}
+
+func newFFTPlan(n int) *fftPlan {
+	p := &fftPlan{n: n, twiddle: make([]complex128, n/2), rev: make([]int, n)}
+	for k := range p.twiddle {
+		p.twiddle[k] = cmplx.Rect(1, -2*math.Pi*float64(k)/float64(n))
+	}
+	shift := 64 - bits.Len(uint(n-1))
+	for i := range p.rev {
+		p.rev[i] = int(bits.Reverse64(uint64(i)) >> uint(shift))
+	}
+	return p
+}
+
+// transform does an in-place iterative radix-2 transform of x; inverse
+// conjugates the twiddles and scales by 1/n.
+func (p *fftPlan) transform(x []complex128, inverse bool) {
+	n := p.n
+	for i, j := range p.rev {
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+	for size := 2; size <= n; size <<= 1 {
+		half, step := size/2, n/size
+		for start := 0; start < n; start += size {
+			for k := 0; k < half; k++ {
+				w := p.twiddle[k*step]
+				if inverse {
+					w = cmplx.Conj(w)
+				}
+				a, b := x[start+k], x[start+k+half]*w
+				x[start+k], x[start+k+half] = a+b, a-b
+			}
+		}
+	}
+	if inverse {
+		s := complex(1/float64(n), 0)
+		for i := range x {
+			x[i] *= s
+		}
+	}
+}
+
+func fftInput(n int) []complex128 {
+	x := make([]complex128, n)
+	for i := range x {
+		t := float64(i) / float64(n)
+		x[i] = complex(math.Sin(2*math.Pi*37*t)+0.5*math.Cos(2*math.Pi*1021*t), float64((i*7919)%257)/257-0.5)
+	}
+	return x
+}
+
+func complexChecksum(x []complex128) uint64 {
+	h := newFNV()
+	for _, v := range x {
+		h.mix(math.Float64bits(real(v)))
+		h.mix(math.Float64bits(imag(v)))
+	}
+	return uint64(h)
+}
+
+// checkFFT validates the reference transform against a direct DFT on a
+// few bins and an inverse round trip, so the checksum the workers are
+// held to is known to be right.
+func checkFFT(p *fftPlan, in, out []complex128) error {
+	n := len(in)
+	for _, k := range []int{0, 1, 37, 1021, n / 2, n - 37} {
+		var s complex128
+		for j, v := range in {
+			s += v * cmplx.Rect(1, -2*math.Pi*float64(k*j%n)/float64(n))
+		}
+		if cmplx.Abs(s-out[k]) > 1e-6*float64(n) {
+			return fmt.Errorf("bin %d = %v, direct DFT %v", k, out[k], s)
+		}
+	}
+	back := append([]complex128(nil), out...)
+	p.transform(back, true)
+	for i := range back {
+		if cmplx.Abs(back[i]-in[i]) > 1e-9 {
+			return fmt.Errorf("inverse round trip differs at %d", i)
+		}
+	}
+	return nil
+}
+
+// RunCPUFFT runs forward transforms of the same input, one independent
+// transform per worker at a time.
+func RunCPUFFT(ctx context.Context, dur time.Duration, threads int) Result {
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	p := newFFTPlan(FFTSize)
+	in := fftInput(FFTSize)
+	ref := append([]complex128(nil), in...)
+	p.transform(ref, false)
+	if err := checkFFT(p, in, ref); err != nil {
+		return Result{Name: "FFT", Threads: threads, Err: "reference: " + err.Error()}
+	}
+	want := complexChecksum(ref)
+
+	points, elapsed, lat, err := runWorkers(ctx, dur, threads, func() workFn {
+		x := make([]complex128, FFTSize)
+		n := 0
+		return func() (uint64, error) {
+			copy(x, in)
+			p.transform(x, false)
+			n++
+			if !verifyIteration(n) {
+				return FFTSize, nil
+			}
+			if got := complexChecksum(x); got != want {
+				return 0, verifyErr("spectrum checksum", fmt.Sprintf("%016x", got), fmt.Sprintf("%016x", want))
+			}
+			return FFTSize, nil
+		}
+	})
+	if err != nil {
+		return Result{Name: "FFT", Threads: threads, Err: err.Error()}
+	}
+	return Result{Name: "FFT", Threads: threads, Duration: elapsed, Ops: points, Throughput: perSecond(points, elapsed, PointsPerSec), Latency: lat,
+		Checksum: fmt.Sprintf("fnv64:%016x", want), Notes: fmt.Sprintf("radix-2 complex128, n=%d", FFTSize)}
+}
@@ -25,57 +25,62 @@ import (
 	"compress/gzip"
 	"context"
 	"fmt"
-	"math/rand"
+	"hash/crc32"
 	"runtime"
-	"sync"
 	"time"
 )
 
-func RunCPUGzip(ctx context.Context, dur time.Duration, threads, level int) Result {
+func RunCPUGzip(ctx context.Context, dur time.Duration, threads, level int, corpus string) Result {
 	if threads <= 0 {
 		threads = runtime.NumCPU()
 	}
 	if level < gzip.HuffmanOnly || level > gzip.BestCompression {
 		level = gzip.DefaultCompression
 	}
-	ctx, cancel := context.WithTimeout(ctx, dur)
-	defer cancel()
+	bufSize := compressBlockSize
+	prof := LookupCorpus(corpus)
+	srcTemplate := prof.Generate(bufSize)
 
-	var wg sync.WaitGroup
-	var bytesTotal uint64
-	bufSize := 4 * 1024 * 1024
-
-	seed := rand.New(rand.NewSource(42))
-	srcTemplate := make([]byte, bufSize)
-	for i := range srcTemplate {
-		srcTemplate[i] = byte((i*31 + 7) ^ (i >> 3))
-		if seed.Intn(5) == 0 {
-			srcTemplate[i] ^= 0xFF
-		}
+	var ref bytes.Buffer
+	if err := gzipOnce(&ref, srcTemplate, level); err != nil {
+		return Result{Name: "Gzip Compress", Threads: threads, Err: err.Error()}
 	}
+	want := crc32.Checksum(ref.Bytes(), castagnoli)
 
-	for i := 0; i < threads; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			src := make([]byte, bufSize)
-			copy(src, srcTemplate)
-			var local uint64
-			for {
-				select {
-				case <-ctx.Done():
-					bytesTotal += local
-					return
-				default:
-					var out bytes.Buffer
-					zw, _ := gzip.NewWriterLevel(&out, level)
-					_, _ = zw.Write(src)
-					_ = zw.Close()
-					local += uint64(len(src))
+	total, elapsed, lat, err := runWorkers(ctx, dur, threads, func() workFn {
+		src := make([]byte, bufSize)
+		copy(src, srcTemplate)
+		var out bytes.Buffer
+		n := 0
+		return func() (uint64, error) {
+			out.Reset()
+			if err := gzipOnce(&out, src, level); err != nil {
+				return 0, err
+			}
+			n++
+			if verifyIteration(n) {
+				if got := crc32.Checksum(out.Bytes(), castagnoli); got != want {
+					return 0, verifyErr("gzip output crc32c", crcHex(got), crcHex(want))
 				}
 			}
-		}()
+			return uint64(len(src)), nil
+		}
+	})
+	if err != nil {
+		return Result{Name: "Gzip Compress", Threads: threads, Err: err.Error()}
+	}
+	return Result{Name: "Gzip Compress", Threads: threads, Duration: elapsed, Bytes: total, Throughput: perSecond(total, elapsed, BytesPerSec), Latency: lat,
+		Checksum: crcHex(want), Corpus: prof.String(), Metrics: map[string]float64{"ratio": ratio(srcTemplate, ref.Bytes())},
+		Notes: fmt.Sprintf("level=%d, %s", level, compressionRatio(srcTemplate, ref.Bytes()))}
+}
+
+func gzipOnce(out *bytes.Buffer, src []byte, level int) error {
+	zw, err := gzip.NewWriterLevel(out, level)
+	if err != nil {
+		return err
+	}
+	if _, err := zw.Write(src); err != nil {
+		return err
 	}
-	wg.Wait()
-	return Result{Name: "Gzip Compress", Threads: threads, Duration: dur, Bytes: bytesTotal, Unit: "B/s", Notes: "level=" + fmt.Sprint(level)}
+	return zw.Close()
 }
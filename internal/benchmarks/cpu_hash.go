@@ -0,0 +1,161 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"runtime"
+	"time"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// HashAlg selects the digest used by RunCPUHash.
+type HashAlg int
+
+const (
+	SHA512 HashAlg = iota
+	BLAKE2b
+	SHA256
+)
+
+func (a HashAlg) String() string {
+	switch a {
+	case BLAKE2b:
+		return "BLAKE2b"
+	case SHA256:
+		return "SHA-256"
+	}
+	return "SHA-512"
+}
+
+func (a HashAlg) tag() string {
+	switch a {
+	case BLAKE2b:
+		return "blake2b"
+	case SHA256:
+		return "sha256"
+	}
+	return "sha512"
+}
+
+func (a HashAlg) new() hash.Hash {
+	switch a {
+	case BLAKE2b:
+		h, _ := blake2b.New512(nil)
+		return h
+	case SHA256:
+		return sha256.New()
+	}
+	return sha512.New()
+}
+
+// CryptoBufSizes are the buffer sizes offered for the hash and AEAD
+// throughput tests.
+var CryptoBufSizes = []int{1 << 10, 8 << 10, 64 << 10, 1 << 20}
+
+// DefaultCryptoBuf is the buffer size the scored results refer to.
+const DefaultCryptoBuf = 8 << 10
+
+// cryptoBatch is the least a crypto worker processes per timed call, so
+// the clock reads cost nothing next to small messages.
+const cryptoBatch = 256 << 10
+
+// cryptoMsgs is how many size-byte messages make up one call.
+func cryptoMsgs(size int) int { return max(1, cryptoBatch/size) }
+
+// BufLabel formats a message size the way openssl speed does: 64 B, 8 KB.
+func BufLabel(n int) string {
+	switch {
+	case n >= 1<<20 && n%(1<<20) == 0:
+		return fmt.Sprintf("%d MB", n>>20)
+	case n >= 1<<10 && n%(1<<10) == 0:
+		return fmt.Sprintf("%d KB", n>>10)
+	}
+	return fmt.Sprintf("%d B", n)
+}
+
+func newCryptoBuf(n int) []byte {
+	b := make([]byte, n)
+	for j := range b {
+		b[j] = byte(j*131 + 17)
+	}
+	return b
+}
+
+// hashChain is shaChain for any digest: each digest is fed back into the
+// head of b before the next round.
+func hashChain(h hash.Hash, b []byte) []byte {
+	d := make([]byte, h.Size())
+	for i := 0; i < shaCycle; i++ {
+		copy(b, d)
+		h.Reset()
+		h.Write(b)
+		d = h.Sum(d[:0])
+	}
+	return d
+}
+
+// RunCPUHash measures digest throughput over bufSize-byte messages.
+func RunCPUHash(ctx context.Context, dur time.Duration, threads int, alg HashAlg, bufSize int) Result {
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	if bufSize < 64 {
+		bufSize = DefaultCryptoBuf
+	}
+	name := alg.String()
+	want := hashChain(alg.new(), newCryptoBuf(bufSize))
+
+	msgs := cryptoMsgs(bufSize)
+	total, elapsed, _, err := runWorkers(ctx, dur, threads, func() workFn {
+		h := alg.new()
+		b := newCryptoBuf(bufSize)
+		d := make([]byte, h.Size())
+		n := 0
+		return func() (uint64, error) {
+			for range msgs {
+				copy(b, d)
+				h.Reset()
+				h.Write(b)
+				d = h.Sum(d[:0])
+				if n++; n == shaCycle {
+					if string(d) != string(want) {
+						return 0, verifyErr(name+" chain", hex.EncodeToString(d[:8]), hex.EncodeToString(want[:8]))
+					}
+					n = 0
+					clear(d)
+				}
+			}
+			return uint64(msgs * bufSize), nil
+		}
+	})
+	if err != nil {
+		return Result{Name: name, Threads: threads, Err: err.Error()}
+	}
+	return Result{Name: name, Threads: threads, Duration: elapsed, Bytes: total, Throughput: perSecond(total, elapsed, BytesPerSec),
+		Checksum: alg.tag() + ":" + hex.EncodeToString(want), Notes: "buf=" + BufLabel(bufSize)}
+}
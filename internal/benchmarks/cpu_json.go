@@ -0,0 +1,124 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"math"
+	"sort"
+	"time"
+)
+
+// RunCPUJSONEncode marshals the records behind the payload and checks
+// the output byte for byte; throughput is bytes of JSON produced.
+func RunCPUJSONEncode(ctx context.Context, dur time.Duration, threads int, shape JSONShape) Result {
+	recs := genRecords(shape)
+	payload, err := json.Marshal(recs)
+	if err != nil {
+		return Result{Name: "JSON Encode", Err: err.Error()}
+	}
+	want := recsChecksum(recs)
+	return runJSON(ctx, dur, threads, "JSON Encode", shape, payload, want, func() jsonFn {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		n := 0
+		return func(payload []byte) (int, error) {
+			buf.Reset()
+			if err := enc.Encode(recs); err != nil {
+				return 0, err
+			}
+			// Encoder appends a newline that Marshal does not
+			out := bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+			n++
+			if verifyIteration(n) && !bytes.Equal(out, payload) {
+				return 0, verifyErr("encoded crc32c", crcHex(crc32.Checksum(out, castagnoli)), crcHex(crc32.Checksum(payload, castagnoli)))
+			}
+			return len(out), nil
+		}
+	})
+}
+
+// RunCPUJSONParseMap decodes the same payload as RunCPUJSONParse into
+// untyped map[string]any values, the way schemaless handlers do.
+func RunCPUJSONParseMap(ctx context.Context, dur time.Duration, threads int, shape JSONShape) Result {
+	payload, want := genJSON(shape)
+	return runJSON(ctx, dur, threads, "JSON Parse Map", shape, payload, want, func() jsonFn {
+		n := 0
+		return func(payload []byte) (int, error) {
+			var out []any
+			if err := json.Unmarshal(payload, &out); err != nil {
+				return 0, err
+			}
+			n++
+			if !verifyIteration(n) {
+				return len(payload), nil
+			}
+			got, err := anyChecksum(out)
+			if err != nil {
+				return 0, err
+			}
+			if got != want {
+				return 0, verifyErr("decoded records checksum", fmt.Sprintf("%016x", got), fmt.Sprintf("%016x", want))
+			}
+			return len(payload), nil
+		}
+	})
+}
+
+// anyChecksum is recsChecksum over the generic decode of the same
+// records, so both decoders have to agree on one reference.
+func anyChecksum(recs []any) (uint64, error) {
+	h := newFNV()
+	for _, v := range recs {
+		for v != nil {
+			rec, ok := v.(map[string]any)
+			if !ok {
+				return 0, fmt.Errorf("record is %T, not an object", v)
+			}
+			id, _ := rec["id"].(float64)
+			name, _ := rec["name"].(string)
+			vals, _ := rec["values"].([]any)
+			tags, _ := rec["tags"].(map[string]any)
+			h.mix(uint64(int(id)))
+			h.str(name)
+			for _, x := range vals {
+				f, _ := x.(float64)
+				h.mix(math.Float64bits(f))
+			}
+			keys := make([]string, 0, len(tags))
+			for k := range tags {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				s, _ := tags[k].(string)
+				h.str(k)
+				h.str(s)
+			}
+			v = rec["child"]
+		}
+	}
+	return uint64(h), nil
+}
@@ -25,9 +25,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"math/rand"
 	"runtime"
-	"sync"
+	"sort"
 	"time"
 )
 
@@ -36,53 +37,171 @@ type sampleRec struct {
 	Name   string            `json:"name"`
 	Values []float64         `json:"values"`
 	Tags   map[string]string `json:"tags"`
+	Child  *sampleRec        `json:"child,omitempty"`
 }
 
-func genJSON() []byte {
-	recs := make([]sampleRec, 500)
-	r := rand.New(rand.NewSource(99))
+// JSONShape describes the generated payload: how many top-level records,
+// how deeply each one nests, and whether the bulk of the bytes are
+// numbers or strings.
+type JSONShape struct {
+	Records     int
+	Depth       int
+	StringHeavy bool
+}
+
+// DefaultJSONShape is the payload the scored results refer to.
+var DefaultJSONShape = JSONShape{Records: 500, Depth: 1}
+
+func (s JSONShape) norm() JSONShape {
+	if s.Records <= 0 {
+		s.Records = DefaultJSONShape.Records
+	}
+	s.Depth = min(max(s.Depth, 1), 32)
+	return s
+}
+
+func (s JSONShape) String() string {
+	s = s.norm()
+	kind := "number-heavy"
+	if s.StringHeavy {
+		kind = "string-heavy"
+	}
+	return fmt.Sprintf("%d records, depth %d, %s", s.Records, s.Depth, kind)
+}
+
+// fnvMix is the FNV-1a step the JSON checksums are built from.
+type fnvMix uint64
+
+func newFNV() fnvMix { return 1469598103934665603 }
+
+func (h *fnvMix) mix(v uint64) { *h = (*h ^ fnvMix(v)) * 1099511628211 }
+
+func (h *fnvMix) str(s string) {
+	h.mix(uint64(len(s)))
+	for i := 0; i < len(s); i++ {
+		h.mix(uint64(s[i]))
+	}
+}
+
+// recsChecksum folds every decoded field into a single value so a decode
+// that silently drops or mangles data is caught.
+func recsChecksum(recs []sampleRec) uint64 {
+	h := newFNV()
 	for i := range recs {
+		for rec := &recs[i]; rec != nil; rec = rec.Child {
+			h.mix(uint64(rec.ID))
+			h.str(rec.Name)
+			for _, v := range rec.Values {
+				h.mix(math.Float64bits(v))
+			}
+			keys := make([]string, 0, len(rec.Tags))
+			for k := range rec.Tags {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				h.str(k)
+				h.str(rec.Tags[k])
+			}
+		}
+	}
+	return uint64(h)
+}
+
+// jsonWords feed the string-heavy tags; a few need escaping on purpose.
+var jsonWords = []string{"alpha", "bravo", "charlie", "delta", "naïve", "café", `say "hi"`, "tab\there", "zone-eu-west-1", "prod", "€uro", "x"}
+
+func genRec(r *rand.Rand, id int, shape JSONShape) sampleRec {
+	if !shape.StringHeavy {
 		vals := make([]float64, 64)
 		for j := range vals {
 			vals[j] = r.NormFloat64()
 		}
 		tags := map[string]string{"k": "v", "env": "prod", "zone": "eu"}
-		recs[i] = sampleRec{ID: i, Name: fmt.Sprintf("rec-%d", i), Values: vals, Tags: tags}
+		return sampleRec{ID: id, Name: fmt.Sprintf("rec-%d", id), Values: vals, Tags: tags}
+	}
+	vals := make([]float64, 4)
+	for j := range vals {
+		vals[j] = float64(r.Intn(1000))
+	}
+	tags := make(map[string]string, 24)
+	for j := 0; j < 24; j++ {
+		var b []byte
+		for n := 1 + r.Intn(5); n > 0; n-- {
+			b = append(b, jsonWords[r.Intn(len(jsonWords))]...)
+			b = append(b, ' ')
+		}
+		tags[fmt.Sprintf("tag%02d", j)] = string(b)
+	}
+	return sampleRec{ID: id, Name: fmt.Sprintf("record %d of the string-heavy set", id), Values: vals, Tags: tags}
+}
+
+// genRecords builds the records for shape; nested records hang off Child.
+func genRecords(shape JSONShape) []sampleRec {
+	shape = shape.norm()
+	recs := make([]sampleRec, shape.Records)
+	r := rand.New(rand.NewSource(99))
+	for i := range recs {
+		recs[i] = genRec(r, i, shape)
+		for d, parent := 1, &recs[i]; d < shape.Depth; d++ {
+			c := genRec(r, i*shape.Depth+d, shape)
+			parent.Child = &c
+			parent = parent.Child
+		}
 	}
+	return recs
+}
+
+// genJSON returns the payload and the checksum of the records it encodes.
+func genJSON(shape JSONShape) ([]byte, uint64) {
+	recs := genRecords(shape)
 	b, _ := json.Marshal(recs)
-	return b
+	return b, recsChecksum(recs)
 }
 
-func RunCPUJSONParse(ctx context.Context, dur time.Duration, threads int) Result {
+// jsonFn runs one iteration over payload and returns the bytes it
+// accounts for.
+type jsonFn func(payload []byte) (int, error)
+
+// runJSON times fn on every worker; newWorker returns each worker's
+// function so it can keep its own scratch state.
+func runJSON(ctx context.Context, dur time.Duration, threads int, name string, shape JSONShape, payload []byte, want uint64, newWorker func() jsonFn) Result {
 	if threads <= 0 {
 		threads = runtime.NumCPU()
 	}
-	ctx, cancel := context.WithTimeout(ctx, dur)
-	defer cancel()
-
-	payload := genJSON()
-	var wg sync.WaitGroup
-	var bytesOK uint64
-
-	for i := 0; i < threads; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			var local uint64
-			for {
-				select {
-				case <-ctx.Done():
-					bytesOK += local
-					return
-				default:
-					dec := json.NewDecoder(bytes.NewReader(payload))
-					var out []sampleRec
-					_ = dec.Decode(&out)
-					local += uint64(len(payload))
+	total, elapsed, lat, err := runWorkers(ctx, dur, threads, func() workFn {
+		fn := newWorker()
+		return func() (uint64, error) {
+			n, err := fn(payload)
+			return uint64(n), err
+		}
+	})
+	if err != nil {
+		return Result{Name: name, Threads: threads, Err: err.Error()}
+	}
+	return Result{Name: name, Threads: threads, Duration: elapsed, Bytes: total, Throughput: perSecond(total, elapsed, BytesPerSec), Latency: lat,
+		Checksum: fmt.Sprintf("fnv64:%016x", want), Notes: shape.norm().String()}
+}
+
+// RunCPUJSONParse streams the payload through a json.Decoder into typed
+// structs.
+func RunCPUJSONParse(ctx context.Context, dur time.Duration, threads int, shape JSONShape) Result {
+	payload, want := genJSON(shape)
+	return runJSON(ctx, dur, threads, "JSON Parse", shape, payload, want, func() jsonFn {
+		n := 0
+		return func(payload []byte) (int, error) {
+			dec := json.NewDecoder(bytes.NewReader(payload))
+			var out []sampleRec
+			if err := dec.Decode(&out); err != nil {
+				return 0, err
+			}
+			n++
+			if verifyIteration(n) {
+				if got := recsChecksum(out); got != want {
+					return 0, verifyErr("decoded records checksum", fmt.Sprintf("%016x", got), fmt.Sprintf("%016x", want))
 				}
 			}
-		}()
-	}
-	wg.Wait()
-	return Result{Name: "JSON Parse", Threads: threads, Duration: dur, Bytes: bytesOK, Unit: "B/s"}
+			return len(payload), nil
+		}
+	})
 }
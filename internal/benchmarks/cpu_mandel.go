@@ -0,0 +1,98 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// Mandelbrot frame: the whole set at 1080p, so escape times range from
+// one iteration at the edges to the limit inside the set.
+const (
+	MandelW     = 1920
+	MandelH     = 1080
+	mandelIter  = 256
+	mandelX0    = -0.6
+	mandelY0    = 0.0
+	mandelScale = 3.2 / MandelW
+)
+
+// mandelRows renders rows [lo,hi) into out as escape iteration counts.
+func mandelRows(out []uint16, lo, hi int) {
+	for py := lo; py < hi; py++ {
+		ci := mandelY0 + float64(py-MandelH/2)*mandelScale
+		row := out[py*MandelW : (py+1)*MandelW]
+		for px := range row {
+			cr := mandelX0 + float64(px-MandelW/2)*mandelScale
+			var zr, zi float64
+			n := 0
+			for ; n < mandelIter && zr*zr+zi*zi <= 4; n++ {
+				zr, zi = zr*zr-zi*zi+cr, 2*zr*zi+ci
+			}
+			row[px] = uint16(n)
+		}
+	}
+}
+
+func mandelChecksum(px []uint16) uint64 {
+	h := newFNV()
+	for _, v := range px {
+		h.mix(uint64(v))
+	}
+	return uint64(h)
+}
+
+// RunCPUMandelbrot renders whole frames, rows handed out to threads
+// workers as they finish, and checks each frame against a reference.
+func RunCPUMandelbrot(ctx context.Context, dur time.Duration, threads int) Result {
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	ref := make([]uint16, MandelW*MandelH)
+	mandelRows(ref, 0, MandelH)
+	want := mandelChecksum(ref)
+
+	ctx, cancel := context.WithTimeout(ctx, dur)
+	defer cancel()
+	frame := make([]uint16, MandelW*MandelH)
+	lat := NewHistogram()
+	var frames uint64
+	start := time.Now()
+	for ctx.Err() == nil {
+		t0 := time.Now()
+		parallelFor(ctx, threads, MandelH, 8, func(lo, hi int) { mandelRows(frame, lo, hi) })
+		lat.Record(time.Since(t0))
+		frames++
+		if !verifyIteration(int(frames)) {
+			continue
+		}
+		if got := mandelChecksum(frame); got != want {
+			return Result{Name: "Mandelbrot", Threads: threads, Err: verifyErr("frame checksum", fmt.Sprintf("%016x", got), fmt.Sprintf("%016x", want)).Error()}
+		}
+	}
+	elapsed := time.Since(start)
+	px := frames * MandelW * MandelH
+	return Result{Name: "Mandelbrot", Threads: threads, Duration: elapsed, Ops: px, Throughput: perSecond(px, elapsed, PixelsPerSec), Latency: lat,
+		Checksum: fmt.Sprintf("fnv64:%016x", want), Notes: fmt.Sprintf("%dx%d, %d max iterations", MandelW, MandelH, mandelIter)}
+}
@@ -0,0 +1,86 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// MapKeys is how many distinct string keys RunCPUMap inserts per
+// iteration; large enough that the table does not fit in L2.
+const MapKeys = 1 << 17
+
+// RunCPUMap builds a map[string]uint32 from scratch (so growth is
+// included), looks every key up, probes as many missing keys, and
+// deletes every other key. Each of those counts as one op.
+func RunCPUMap(ctx context.Context, dur time.Duration, threads int) Result {
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	keys := make([]string, MapKeys)
+	misses := make([]string, MapKeys)
+	var want uint64
+	for i := range keys {
+		keys[i] = fmt.Sprintf("user:%08x:session", uint32(i)*2654435761)
+		misses[i] = fmt.Sprintf("user:%08x:expired", uint32(i)*2654435761)
+		want += uint64(i)
+	}
+	opsPerIter := uint64(len(keys) + len(keys) + len(misses) + len(keys)/2)
+
+	ops, elapsed, lat, err := runWorkers(ctx, dur, threads, func() workFn {
+		return func() (uint64, error) {
+			m := make(map[string]uint32)
+			for i, k := range keys {
+				m[k] = uint32(i)
+			}
+			var sum uint64
+			for _, k := range keys {
+				sum += uint64(m[k])
+			}
+			found := 0
+			for _, k := range misses {
+				if _, ok := m[k]; ok {
+					found++
+				}
+			}
+			for i := 0; i < len(keys); i += 2 {
+				delete(m, keys[i])
+			}
+			switch {
+			case sum != want:
+				return 0, verifyErr("sum of looked-up values", sum, want)
+			case found != 0:
+				return 0, verifyErr("missing keys found", found, 0)
+			case len(m) != len(keys)/2:
+				return 0, verifyErr("len after deletes", len(m), len(keys)/2)
+			}
+			return opsPerIter, nil
+		}
+	})
+	if err != nil {
+		return Result{Name: "Map ops", Threads: threads, Err: err.Error()}
+	}
+	return Result{Name: "Map ops", Threads: threads, Duration: elapsed, Ops: ops, Throughput: perSecond(ops, elapsed, OpsPerSec), Latency: lat,
+		Notes: fmt.Sprintf("%d string keys: insert, hit, miss, delete", MapKeys)}
+}
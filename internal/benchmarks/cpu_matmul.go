@@ -28,69 +28,160 @@ import (
 	"time"
 )
 
-// RunMatMul performs naive C = A*B on n x n matrices of float64.
-// Reports operations as floating point ops per second (approx 2*n^3) and returns GFLOP/s.
-func RunMatMul(ctx context.Context, dur time.Duration, threads, n int) Result {
+type Precision int
+
+const (
+	Float64 Precision = iota
+	Float32
+)
+
+func (p Precision) String() string {
+	if p == Float32 {
+		return "fp32"
+	}
+	return "fp64"
+}
+
+// MatMulSizes are the matrix sizes offered for RunMatMul.
+var MatMulSizes = []int{512, 1024, 2048}
+
+// DefaultMatMulSize is the matrix size the scored results refer to.
+const DefaultMatMulSize = 1024
+
+// matBlock is the tile edge; three 64x64 fp64 tiles fit comfortably in L1+L2.
+const matBlock = 64
+
+// RunMatMul repeatedly computes C = A*B on n x n matrices with a blocked
+// kernel, splitting the rows of every multiply across threads. Each product
+// is verified against a Freivalds-style checksum (w^T A)(B v), which is
+// exact because the inputs are small multiples of 1/8. Reports FLOP/s
+// (2*n^3 per multiply).
+func RunMatMul(ctx context.Context, dur time.Duration, threads, n int, prec Precision) Result {
 	if n <= 0 {
-		n = 256
+		n = DefaultMatMulSize
 	}
 	if threads <= 0 {
 		threads = runtime.NumCPU()
 	}
+	name := "MatMul"
+	if prec == Float32 {
+		name = "MatMul FP32"
+	}
 	ctx, cancel := context.WithTimeout(ctx, dur)
 	defer cancel()
 
-	type job struct{ repeat int }
-	jobs := make(chan job, threads)
-	var wg sync.WaitGroup
-	var flops uint64
-
-	worker := func() {
-		defer wg.Done()
-		A := make([]float64, n*n)
-		B := make([]float64, n*n)
-		C := make([]float64, n*n)
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case _, ok := <-jobs:
-				if !ok {
-					return
-				}
-				// i-k-j loop order
-				for i := 0; i < n; i++ {
-					for k := 0; k < n; k++ {
+	var mults uint64
+	var elapsed time.Duration
+	var sum float64
+	var err error
+	if prec == Float32 {
+		mults, elapsed, sum, err = matmulRun[float32](ctx, threads, n)
+	} else {
+		mults, elapsed, sum, err = matmulRun[float64](ctx, threads, n)
+	}
+	notes := fmt.Sprintf("n=%d, %s, block=%d", n, prec, matBlock)
+	if err != nil {
+		return Result{Name: name, Threads: threads, Err: err.Error(), Notes: notes}
+	}
+
+	flops := 2 * uint64(n) * uint64(n) * uint64(n) * mults
+	return Result{Name: name, Threads: threads, Duration: elapsed, Ops: flops, Throughput: perSecond(flops, elapsed, FLOPS),
+		Checksum: fmt.Sprintf("wCv=%.0f", sum), Notes: notes}
+}
+
+func matmulRun[T float32 | float64](ctx context.Context, threads, n int) (uint64, time.Duration, float64, error) {
+	A := make([]T, n*n)
+	B := make([]T, n*n)
+	C := make([]T, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			A[i*n+j] = T((i*7+j*3)%17-8) / 8
+			B[i*n+j] = T((i*5+j*11)%13-6) / 8
+		}
+	}
+	want := matChecksumRef(A, B, n)
+
+	var mults uint64
+	start := time.Now()
+	for ctx.Err() == nil {
+		clear(C)
+		var wg sync.WaitGroup
+		for t := 0; t < threads; t++ {
+			lo, hi := t*n/threads, (t+1)*n/threads
+			if lo == hi {
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer pinWorker(ctx, t)()
+				matmulBlocked(A, B, C, n, lo, hi)
+			}()
+		}
+		wg.Wait()
+		if got := matChecksum(C, n); got != want {
+			return mults, time.Since(start), want, verifyErr("product checksum w^T C v", got, want)
+		}
+		mults++
+	}
+	return mults, time.Since(start), want, nil
+}
+
+// matmulBlocked accumulates rows [lo, hi) of A*B into C, tile by tile.
+func matmulBlocked[T float32 | float64](A, B, C []T, n, lo, hi int) {
+	for ii := lo; ii < hi; ii += matBlock {
+		iEnd := min(ii+matBlock, hi)
+		for kk := 0; kk < n; kk += matBlock {
+			kEnd := min(kk+matBlock, n)
+			for jj := 0; jj < n; jj += matBlock {
+				jEnd := min(jj+matBlock, n)
+				for i := ii; i < iEnd; i++ {
+					crow := C[i*n+jj : i*n+jEnd]
+					for k := kk; k < kEnd; k++ {
 						aik := A[i*n+k]
-						row := i * n
-						col := k * n
-						for j := 0; j < n; j++ {
-							C[row+j] += aik * B[col+j]
+						brow := B[k*n+jj : k*n+jEnd]
+						for j := range crow {
+							crow[j] += aik * brow[j]
 						}
 					}
 				}
-				// count 2*n^3 flops per multiply-add
-				flops += uint64(2) * uint64(n) * uint64(n) * uint64(n)
 			}
 		}
 	}
-	for w := 0; w < threads; w++ {
-		wg.Add(1)
-		go worker()
-	}
+}
 
-	start := time.Now()
-	for time.Since(start) < dur {
-		select {
-		case <-ctx.Done():
-			break
-		default:
-			jobs <- job{1}
+// matWeights are the small integer vectors w and v used by the checksum.
+func matW(i int) float64 { return float64(i%5 + 1) }
+func matV(j int) float64 { return float64(j%3 + 1) }
+
+// matChecksum computes w^T C v.
+func matChecksum[T float32 | float64](C []T, n int) float64 {
+	var s float64
+	for i := 0; i < n; i++ {
+		var row float64
+		for j := 0; j < n; j++ {
+			row += float64(C[i*n+j]) * matV(j)
 		}
+		s += matW(i) * row
 	}
-	close(jobs)
-	wg.Wait()
+	return s
+}
 
-	gflops := float64(flops) / 1e9 / dur.Seconds()
-	return Result{Name: "MatMul", Threads: threads, Duration: dur, Ops: uint64(gflops * 1e6), Unit: "GFLOP/s", Notes: fmt.Sprintf("n=%d", n)}
+// matChecksumRef computes (w^T A)(B v) in O(n^2), the expected w^T C v.
+func matChecksumRef[T float32 | float64](A, B []T, n int) float64 {
+	wa := make([]float64, n)
+	for i := 0; i < n; i++ {
+		for k := 0; k < n; k++ {
+			wa[k] += matW(i) * float64(A[i*n+k])
+		}
+	}
+	var s float64
+	for k := 0; k < n; k++ {
+		var bv float64
+		for j := 0; j < n; j++ {
+			bv += float64(B[k*n+j]) * matV(j)
+		}
+		s += wa[k] * bv
+	}
+	return s
 }
@@ -0,0 +1,183 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"time"
+)
+
+// NBodies and NBodySteps size one n-body run: every run starts from the
+// same state, so its final positions can be checked.
+const (
+	NBodies    = 1024
+	NBodySteps = 8
+	nbodyDT    = 1e-3
+	nbodySoft  = 1e-2 // softening, keeps close encounters finite
+)
+
+type bodies struct {
+	x, y, z    []float64
+	vx, vy, vz []float64
+	ax, ay, az []float64
+	m          []float64
+}
+
+func newBodies(n int) *bodies {
+	b := &bodies{}
+	for _, s := range []*[]float64{&b.x, &b.y, &b.z, &b.vx, &b.vy, &b.vz, &b.ax, &b.ay, &b.az, &b.m} {
+		*s = make([]float64, n)
+	}
+	return b
+}
+
+// nbodyInit scatters bodies in a unit ball with small random velocities.
+func nbodyInit(n int) *bodies {
+	r := rand.New(rand.NewSource(3))
+	b := newBodies(n)
+	for i := 0; i < n; i++ {
+		for {
+			x, y, z := 2*r.Float64()-1, 2*r.Float64()-1, 2*r.Float64()-1
+			if x*x+y*y+z*z <= 1 {
+				b.x[i], b.y[i], b.z[i] = x, y, z
+				break
+			}
+		}
+		b.vx[i], b.vy[i], b.vz[i] = 0.1*r.NormFloat64(), 0.1*r.NormFloat64(), 0.1*r.NormFloat64()
+		b.m[i] = (0.5 + r.Float64()) / float64(n)
+	}
+	return b
+}
+
+func (b *bodies) copyFrom(o *bodies) {
+	copy(b.x, o.x)
+	copy(b.y, o.y)
+	copy(b.z, o.z)
+	copy(b.vx, o.vx)
+	copy(b.vy, o.vy)
+	copy(b.vz, o.vz)
+	copy(b.m, o.m)
+}
+
+// accel computes the all-pairs acceleration of bodies [lo,hi). The inner
+// loop order does not depend on the split, so results are identical for
+// any thread count.
+func (b *bodies) accel(lo, hi int) {
+	n := len(b.x)
+	for i := lo; i < hi; i++ {
+		var ax, ay, az float64
+		xi, yi, zi := b.x[i], b.y[i], b.z[i]
+		for j := 0; j < n; j++ {
+			dx, dy, dz := b.x[j]-xi, b.y[j]-yi, b.z[j]-zi
+			d2 := dx*dx + dy*dy + dz*dz + nbodySoft*nbodySoft
+			inv := b.m[j] / (d2 * math.Sqrt(d2))
+			ax += dx * inv
+			ay += dy * inv
+			az += dz * inv
+		}
+		b.ax[i], b.ay[i], b.az[i] = ax, ay, az
+	}
+}
+
+// step advances one symplectic Euler step with threads workers.
+func (b *bodies) step(ctx context.Context, threads int) {
+	parallelFor(ctx, threads, len(b.x), 32, b.accel)
+	for i := range b.x {
+		b.vx[i] += b.ax[i] * nbodyDT
+		b.vy[i] += b.ay[i] * nbodyDT
+		b.vz[i] += b.az[i] * nbodyDT
+		b.x[i] += b.vx[i] * nbodyDT
+		b.y[i] += b.vy[i] * nbodyDT
+		b.z[i] += b.vz[i] * nbodyDT
+	}
+}
+
+// energy is kinetic plus (softened) potential energy.
+func (b *bodies) energy() float64 {
+	var e float64
+	for i := range b.x {
+		e += 0.5 * b.m[i] * (b.vx[i]*b.vx[i] + b.vy[i]*b.vy[i] + b.vz[i]*b.vz[i])
+		for j := i + 1; j < len(b.x); j++ {
+			dx, dy, dz := b.x[j]-b.x[i], b.y[j]-b.y[i], b.z[j]-b.z[i]
+			e -= b.m[i] * b.m[j] / math.Sqrt(dx*dx+dy*dy+dz*dz+nbodySoft*nbodySoft)
+		}
+	}
+	return e
+}
+
+func (b *bodies) checksum() uint64 {
+	h := newFNV()
+	for i := range b.x {
+		h.mix(math.Float64bits(b.x[i]))
+		h.mix(math.Float64bits(b.y[i]))
+		h.mix(math.Float64bits(b.z[i]))
+	}
+	return uint64(h)
+}
+
+// RunCPUNBody advances one gravitational system; with more threads each
+// step's force computation is split between them. Throughput counts
+// pairwise interactions.
+func RunCPUNBody(ctx context.Context, dur time.Duration, threads int) Result {
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	init := nbodyInit(NBodies)
+	ref := newBodies(NBodies)
+	ref.copyFrom(init)
+	e0 := ref.energy()
+	for s := 0; s < NBodySteps; s++ {
+		ref.step(ctx, 1)
+	}
+	// a broken integrator shows up as drifting energy long before the
+	// positions look wrong
+	if e1 := ref.energy(); math.Abs(e1-e0) > 1e-3*math.Abs(e0) {
+		return Result{Name: "N-body", Threads: threads, Err: fmt.Sprintf("reference energy drift %.3g -> %.3g", e0, e1)}
+	}
+	want := ref.checksum()
+
+	ctx, cancel := context.WithTimeout(ctx, dur)
+	defer cancel()
+	b := newBodies(NBodies)
+	lat := NewHistogram()
+	var runs uint64
+	start := time.Now()
+	for ctx.Err() == nil {
+		t0 := time.Now()
+		b.copyFrom(init)
+		for s := 0; s < NBodySteps; s++ {
+			b.step(ctx, threads)
+		}
+		lat.Record(time.Since(t0))
+		if got := b.checksum(); got != want {
+			return Result{Name: "N-body", Threads: threads, Err: verifyErr("final positions checksum", fmt.Sprintf("%016x", got), fmt.Sprintf("%016x", want)).Error()}
+		}
+		runs++
+	}
+	elapsed := time.Since(start)
+	interactions := runs * NBodySteps * NBodies * NBodies
+	return Result{Name: "N-body", Threads: threads, Duration: elapsed, Ops: interactions, Throughput: perSecond(interactions, elapsed, InteractionsPerSec), Latency: lat,
+		Checksum: fmt.Sprintf("fnv64:%016x", want), Notes: fmt.Sprintf("%d bodies × %d steps per run", NBodies, NBodySteps)}
+}
@@ -0,0 +1,132 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// logPatterns are the greps run over every line of the log corpus, in
+// the order of the counts genLogCorpus returns.
+var logPatterns = []string{
+	`^\S+ (?:ERROR|FATAL) `,
+	`" 5\d\d \d+ `,
+	` \d{4,}ms `,
+	`ip=10\.\d{1,3}\.\d{1,3}\.\d{1,3}\b`,
+	`req=[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`,
+}
+
+// genLogCorpus writes access-log lines until size bytes and returns the
+// lines plus how many of them each pattern must match, as decided while
+// generating them.
+func genLogCorpus(size int) ([][]byte, []int) {
+	r := rand.New(rand.NewSource(7))
+	levels := []string{"DEBUG", "INFO", "INFO", "INFO", "WARN", "ERROR", "FATAL"}
+	methods := []string{"GET", "GET", "GET", "POST", "PUT", "DELETE"}
+	paths := []string{"/v1/users/%d", "/v1/orders/%d/items", "/static/app.%d.js", "/healthz?n=%d", "/v1/search?q=item%d"}
+	want := make([]int, len(logPatterns))
+	var lines [][]byte
+	total := 0
+	for total < size {
+		var b strings.Builder
+		lvl := levels[r.Intn(len(levels))]
+		if lvl == "ERROR" || lvl == "FATAL" {
+			want[0]++
+		}
+		status := []int{200, 200, 200, 201, 204, 301, 404, 500, 502, 503}[r.Intn(10)]
+		if status >= 500 {
+			want[1]++
+		}
+		ms := r.Intn(400)
+		if r.Intn(20) == 0 {
+			ms = 1000 + r.Intn(9000)
+			want[2]++
+		}
+		ip := fmt.Sprintf("192.168.%d.%d", r.Intn(256), r.Intn(256))
+		if r.Intn(3) == 0 {
+			ip = fmt.Sprintf("10.%d.%d.%d", r.Intn(256), r.Intn(256), r.Intn(256))
+			want[3]++
+		}
+		fmt.Fprintf(&b, "2025-03-%02dT%02d:%02d:%02d.%03dZ %s [api-%d] \"%s "+paths[r.Intn(len(paths))]+" HTTP/1.1\" %d %d %dms ip=%s",
+			1+r.Intn(28), r.Intn(24), r.Intn(60), r.Intn(60), r.Intn(1000), lvl, r.Intn(16),
+			methods[r.Intn(len(methods))], r.Intn(100000), status, r.Intn(50000), ms, ip)
+		if r.Intn(4) == 0 {
+			fmt.Fprintf(&b, " req=%08x-%04x-%04x-%04x-%012x", r.Uint32(), r.Intn(1<<16), r.Intn(1<<16), r.Intn(1<<16), r.Int63n(1<<48))
+			want[4]++
+		}
+		b.WriteString(` ua="Mozilla/5.0 (X11; Linux x86_64)"`)
+		lines = append(lines, []byte(b.String()))
+		total += b.Len() + 1
+	}
+	return lines, want
+}
+
+// RunCPURegex greps a generated access log with the standard regexp
+// package, line by line, and checks the match counts.
+func RunCPURegex(ctx context.Context, dur time.Duration, threads int) Result {
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	lines, want := genLogCorpus(1 << 20)
+	size := 0
+	for _, l := range lines {
+		size += len(l) + 1
+	}
+	res := make([]*regexp.Regexp, len(logPatterns))
+	for i, p := range logPatterns {
+		res[i] = regexp.MustCompile(p)
+	}
+
+	bytesTotal, elapsed, lat, err := runWorkers(ctx, dur, threads, func() workFn {
+		got := make([]int, len(res))
+		return func() (uint64, error) {
+			clear(got)
+			for _, l := range lines {
+				for i, re := range res {
+					if re.Match(l) {
+						got[i]++
+					}
+				}
+			}
+			for i := range got {
+				if got[i] != want[i] {
+					return 0, verifyErr(fmt.Sprintf("matches of %q", logPatterns[i]), got[i], want[i])
+				}
+			}
+			return uint64(size), nil
+		}
+	})
+	if err != nil {
+		return Result{Name: "Regex", Threads: threads, Err: err.Error()}
+	}
+	h := newFNV()
+	for _, l := range lines {
+		h.str(string(l))
+	}
+	return Result{Name: "Regex", Threads: threads, Duration: elapsed, Bytes: bytesTotal, Throughput: perSecond(bytesTotal, elapsed, BytesPerSec), Latency: lat,
+		Checksum: fmt.Sprintf("fnv64:%016x", uint64(h)), Notes: fmt.Sprintf("%d patterns over %d log lines, matches %v", len(res), len(lines), want)}
+}
@@ -23,51 +23,52 @@ package benchmarks
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/hex"
 	"runtime"
-	"sync"
 	"time"
 )
 
-type shaState struct{ buf []byte }
+// shaCycle is the number of chained hashes after which a worker checks
+// its digest against the reference.
+const shaCycle = 256
+
+func newSHABuf() []byte {
+	b := make([]byte, 8*1024)
+	for j := range b {
+		b[j] = byte(j*131 + 17)
+	}
+	return b
+}
+
+// shaChain hashes b shaCycle times, feeding each digest back into the
+// first 32 bytes, and returns the final digest.
+func shaChain(b []byte) [32]byte {
+	var d [32]byte
+	for i := 0; i < shaCycle; i++ {
+		copy(b[:32], d[:])
+		d = sha256.Sum256(b)
+	}
+	return d
+}
 
 func RunCPUSHA256(ctx context.Context, dur time.Duration, threads int) Result {
 	if threads <= 0 {
 		threads = runtime.NumCPU()
 	}
-	ctx, cancel := context.WithTimeout(ctx, dur)
-	defer cancel()
-
-	var wg sync.WaitGroup
-	var ops uint64
-	states := make([]shaState, threads)
-	for i := range states {
-		states[i] = shaState{buf: make([]byte, 8*1024)}
-	}
+	want := shaChain(newSHABuf())
 
-	done := make(chan struct{})
-	for i := 0; i < threads; i++ {
-		wg.Add(1)
-		st := &states[i]
-		go func(seed byte) {
-			defer wg.Done()
-			var local uint64
-			b := st.buf
-			for {
-				select {
-				case <-ctx.Done():
-					ops += local
-					return
-				default:
-					for j := range b {
-						b[j] ^= seed + byte(j)
-					}
-					_ = sha256.Sum256(b)
-					local++
-				}
+	ops, elapsed, _, err := runWorkers(ctx, dur, threads, func() workFn {
+		b := newSHABuf()
+		return func() (uint64, error) {
+			if d := shaChain(b); d != want {
+				return 0, verifyErr("sha256 chain", hex.EncodeToString(d[:8]), hex.EncodeToString(want[:8]))
 			}
-		}(byte(i))
+			return shaCycle, nil
+		}
+	})
+	if err != nil {
+		return Result{Name: "CPU SHA-256", Threads: threads, Err: err.Error()}
 	}
-	go func() { wg.Wait(); close(done) }()
-	<-done
-	return Result{Name: "CPU SHA-256", Threads: threads, Duration: dur, Ops: ops, Unit: "hash/s"}
+	return Result{Name: "CPU SHA-256", Threads: threads, Duration: elapsed, Ops: ops, Throughput: perSecond(ops, elapsed, HashesPerSec),
+		Checksum: "sha256:" + hex.EncodeToString(want[:])}
 }
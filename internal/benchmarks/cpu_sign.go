@@ -0,0 +1,166 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// SigAlg selects the signature scheme used by RunCPUSign.
+type SigAlg int
+
+const (
+	ECDSAP256 SigAlg = iota
+	Ed25519
+)
+
+func (a SigAlg) String() string {
+	if a == Ed25519 {
+		return "Ed25519"
+	}
+	return "ECDSA P-256"
+}
+
+// signSamples is how many sampled signatures each signing worker keeps;
+// ECDSA signatures are randomised, so the check is a verify, which costs
+// about as much as the sign itself and runs after the clock stops.
+const signSamples = 64
+
+// signer hides the two APIs behind sign/verify of a fixed message.
+type signer struct {
+	sign   func() ([]byte, error)
+	verify func(sig []byte) bool
+}
+
+func newSigner(alg SigAlg, msg []byte) (signer, error) {
+	if alg == Ed25519 {
+		seed := make([]byte, ed25519.SeedSize)
+		for i := range seed {
+			seed[i] = byte(i*13 + 5)
+		}
+		priv := ed25519.NewKeyFromSeed(seed)
+		pub := priv.Public().(ed25519.PublicKey)
+		return signer{
+			sign:   func() ([]byte, error) { return ed25519.Sign(priv, msg), nil },
+			verify: func(sig []byte) bool { return ed25519.Verify(pub, msg, sig) },
+		}, nil
+	}
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return signer{}, err
+	}
+	digest := sha256.Sum256(msg)
+	return signer{
+		sign:   func() ([]byte, error) { return ecdsa.SignASN1(rand.Reader, priv, digest[:]) },
+		verify: func(sig []byte) bool { return ecdsa.VerifyASN1(&priv.PublicKey, digest[:], sig) },
+	}, nil
+}
+
+// RunCPUSign measures signatures (or, with verify set, verifications)
+// per second of a 64-byte message.
+func RunCPUSign(ctx context.Context, dur time.Duration, threads int, alg SigAlg, verify bool) Result {
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	name := alg.String() + " Sign"
+	if verify {
+		name = alg.String() + " Verify"
+	}
+	msg := newCryptoBuf(64)
+	s, err := newSigner(alg, msg)
+	if err != nil {
+		return Result{Name: name, Threads: threads, Err: err.Error()}
+	}
+	ref, err := s.sign()
+	if err != nil {
+		return Result{Name: name, Threads: threads, Err: err.Error()}
+	}
+	// a verifier that accepts anything would make the verify numbers
+	// meaningless, so check a forged signature is rejected first
+	bad := append([]byte(nil), ref...)
+	bad[len(bad)-1] ^= 1
+	if !s.verify(ref) || s.verify(bad) {
+		return Result{Name: name, Threads: threads, Err: name + ": reference signature check failed"}
+	}
+
+	// each signing worker keeps its sampled signatures here for the check
+	// after the clock stops
+	var mu sync.Mutex
+	var kept []*[][]byte
+	ops, elapsed, _, err := runWorkers(ctx, dur, threads, func() workFn {
+		var mine [][]byte
+		mu.Lock()
+		kept = append(kept, &mine)
+		mu.Unlock()
+		n, picked := 0, 0
+		return func() (uint64, error) {
+			n++
+			if verify {
+				if !s.verify(ref) {
+					return 0, errors.New(name + ": valid signature rejected")
+				}
+				return 1, nil
+			}
+			sig, err := s.sign()
+			switch {
+			case err != nil:
+				return 0, err
+			case alg == Ed25519 && !bytes.Equal(sig, ref):
+				return 0, verifyErr("ed25519 signature", hex.EncodeToString(sig[:8]), hex.EncodeToString(ref[:8]))
+			case alg == ECDSAP256 && verifyIteration(n):
+				if len(mine) < signSamples {
+					mine = append(mine, sig)
+				} else {
+					mine[picked%signSamples] = sig
+				}
+				picked++
+			}
+			return 1, nil
+		}
+	})
+	if err != nil {
+		return Result{Name: name, Threads: threads, Err: err.Error()}
+	}
+	for _, sigs := range kept {
+		for _, sig := range *sigs {
+			if !s.verify(sig) {
+				return Result{Name: name, Threads: threads, Err: name + ": signature does not verify"}
+			}
+		}
+	}
+	res := Result{Name: name, Threads: threads, Duration: elapsed, Ops: ops, Throughput: perSecond(ops, elapsed, OpsPerSec)}
+	if alg == Ed25519 {
+		// Ed25519 is deterministic; ECDSA signatures differ on every call
+		res.Checksum = "ed25519:" + hex.EncodeToString(ref)
+	}
+	return res
+}
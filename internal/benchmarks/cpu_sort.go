@@ -0,0 +1,96 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sort"
+	"time"
+)
+
+type sortRec struct {
+	key uint64
+	id  uint32
+}
+
+// SortItems is the slice length RunCPUSort sorts per iteration.
+const SortItems = 1 << 18
+
+func sortOrderChecksum(recs []sortRec) uint64 {
+	h := newFNV()
+	for _, r := range recs {
+		h.mix(uint64(r.id))
+	}
+	return uint64(h)
+}
+
+// RunCPUSort sorts a copy of the same shuffled records with sort.Slice
+// on every iteration; the comparison closure and its unpredictable
+// branches are the point. Keys are unique, so the order is too.
+func RunCPUSort(ctx context.Context, dur time.Duration, threads int) Result {
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	r := rand.New(rand.NewSource(42))
+	src := make([]sortRec, SortItems)
+	seen := make(map[uint64]bool, SortItems)
+	for i := range src {
+		k := r.Uint64()
+		for seen[k] {
+			k = r.Uint64()
+		}
+		seen[k] = true
+		src[i] = sortRec{key: k, id: uint32(i)}
+	}
+	ref := append([]sortRec(nil), src...)
+	sort.Slice(ref, func(i, j int) bool { return ref[i].key < ref[j].key })
+	for i := 1; i < len(ref); i++ {
+		if ref[i-1].key >= ref[i].key {
+			return Result{Name: "Sort", Threads: threads, Err: "reference sort is not ordered"}
+		}
+	}
+	want := sortOrderChecksum(ref)
+
+	items, elapsed, lat, err := runWorkers(ctx, dur, threads, func() workFn {
+		work := make([]sortRec, len(src))
+		n := 0
+		return func() (uint64, error) {
+			copy(work, src)
+			sort.Slice(work, func(i, j int) bool { return work[i].key < work[j].key })
+			n++
+			if !verifyIteration(n) {
+				return uint64(len(work)), nil
+			}
+			if got := sortOrderChecksum(work); got != want {
+				return 0, verifyErr("sorted order checksum", fmt.Sprintf("%016x", got), fmt.Sprintf("%016x", want))
+			}
+			return uint64(len(work)), nil
+		}
+	})
+	if err != nil {
+		return Result{Name: "Sort", Threads: threads, Err: err.Error()}
+	}
+	return Result{Name: "Sort", Threads: threads, Duration: elapsed, Ops: items, Throughput: perSecond(items, elapsed, ItemsPerSec), Latency: lat,
+		Checksum: fmt.Sprintf("fnv64:%016x", want), Notes: fmt.Sprintf("sort.Slice, %d records", SortItems)}
+}
@@ -0,0 +1,198 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	metaCreate = iota
+	metaWrite
+	metaFsync
+	metaStat
+	metaRename
+	metaDelete
+	metaOpCount
+)
+
+var metaOpNames = [metaOpCount]string{"create", "write", "fsync", "stat", "rename", "delete"}
+
+const (
+	metaBatch    = 1000
+	metaFileSize = 4 * 1024
+)
+
+// RunDiskMeta creates, writes, fsyncs, stats, renames and deletes batches of
+// small files in a scratch directory under path, one subdirectory per
+// worker. Throughput is total metadata/IO operations per second; the
+// per-operation rates go to Metrics and the fsync latency to Latency.
+func RunDiskMeta(ctx context.Context, dur time.Duration, threads int, path string) Result {
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	ctx, cancel := context.WithTimeout(ctx, dur)
+	defer cancel()
+
+	root, err := os.MkdirTemp(path, "benchy-meta-")
+	if err != nil {
+		return Result{Name: "Disk metadata", Threads: threads, Err: err.Error()}
+	}
+	defer os.RemoveAll(root)
+
+	payload := make([]byte, metaFileSize)
+	_, _ = io.ReadFull(rand.Reader, payload)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var counts [metaOpCount]uint64
+	var firstErr error
+	lat := NewHistogram()
+
+	start := time.Now()
+	for w := 0; w < threads; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			defer pinWorker(ctx, w)()
+			var local [metaOpCount]uint64
+			h := NewHistogram()
+			err := metaWorker(ctx, filepath.Join(root, fmt.Sprintf("w%d", w)), payload, &local, h)
+			mu.Lock()
+			for i, c := range local {
+				counts[i] += c
+			}
+			lat.Merge(h)
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+			mu.Unlock()
+		}(w)
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	if firstErr != nil {
+		return Result{Name: "Disk metadata", Threads: threads, Err: firstErr.Error()}
+	}
+
+	var total uint64
+	metrics := make(map[string]float64, metaOpCount)
+	notes := make([]string, 0, metaOpCount)
+	for i, c := range counts {
+		total += c
+		rate := float64(c) / elapsed.Seconds()
+		metrics[metaOpNames[i]+"_per_s"] = rate
+		notes = append(notes, fmt.Sprintf("%s %.0f/s", metaOpNames[i], rate))
+	}
+	return Result{
+		Name: "Disk metadata", Threads: threads, Duration: elapsed, Ops: total, Throughput: perSecond(total, elapsed, OpsPerSec),
+		Latency: lat, Metrics: metrics, Checksum: crcHex(crc32.Checksum(payload, castagnoli)),
+		Notes: strings.Join(notes, ", ") + "; latency=fsync",
+	}
+}
+
+func metaWorker(ctx context.Context, dir string, payload []byte, counts *[metaOpCount]uint64, h *Histogram) error {
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		return err
+	}
+	names := make([]string, metaBatch)
+	for round := 0; ; round++ {
+		for i := range names {
+			names[i] = filepath.Join(dir, fmt.Sprintf("f%d-%d", round, i))
+		}
+		// each phase runs over the whole batch so the directory really
+		// holds thousands of entries while we stat and rename
+		for _, n := range names {
+			if ctx.Err() != nil {
+				return nil
+			}
+			f, err := os.Create(n)
+			if err != nil {
+				return err
+			}
+			counts[metaCreate]++
+			if _, err := f.Write(payload); err != nil {
+				_ = f.Close()
+				return err
+			}
+			counts[metaWrite]++
+			t0 := time.Now()
+			if err := f.Sync(); err != nil {
+				_ = f.Close()
+				return err
+			}
+			h.Record(time.Since(t0))
+			counts[metaFsync]++
+			if err := f.Close(); err != nil {
+				return err
+			}
+		}
+		for _, n := range names {
+			if ctx.Err() != nil {
+				return nil
+			}
+			fi, err := os.Stat(n)
+			if err != nil {
+				return err
+			}
+			if fi.Size() != metaFileSize {
+				return verifyErr(filepath.Base(n)+" size", fi.Size(), metaFileSize)
+			}
+			counts[metaStat]++
+		}
+		for _, n := range names {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err := os.Rename(n, n+".r"); err != nil {
+				return err
+			}
+			counts[metaRename]++
+		}
+		// spot-check that a renamed file still holds what was written
+		if got, err := os.ReadFile(names[0] + ".r"); err != nil {
+			return err
+		} else if !bytes.Equal(got, payload) {
+			return verifyErr(filepath.Base(names[0])+" crc32c", crcHex(crc32.Checksum(got, castagnoli)), crcHex(crc32.Checksum(payload, castagnoli)))
+		}
+		for _, n := range names {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err := os.Remove(n + ".r"); err != nil {
+				return err
+			}
+			counts[metaDelete]++
+		}
+	}
+}
@@ -21,17 +21,16 @@
 package benchmarks
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
+	"hash/crc32"
 	"io"
 	"os"
 	"time"
 )
 
 func RunDiskSeq(ctx context.Context, dur time.Duration, path string) Result {
-	ctx, cancel := context.WithTimeout(ctx, dur)
-	defer cancel()
-
 	chunk := make([]byte, 64*1024*1024)
 	_, _ = io.ReadFull(rand.Reader, chunk)
 
@@ -40,12 +39,12 @@ func RunDiskSeq(ctx context.Context, dur time.Duration, path string) Result {
 	if err != nil {
 		return Result{Name: "Disk seq R/W", Err: err.Error()}
 	}
+	defer os.Remove(path)
 	var wBytes uint64
 	for time.Since(start) < dur {
-		select {
-		case <-ctx.Done():
-			break
-		default:
+		if ctx.Err() != nil {
+			_ = f.Close()
+			return Result{Name: "Disk seq R/W", Err: "canceled"}
 		}
 		n, err := f.Write(chunk)
 		if err != nil {
@@ -54,27 +53,36 @@ func RunDiskSeq(ctx context.Context, dur time.Duration, path string) Result {
 		}
 		wBytes += uint64(n)
 	}
-	_ = f.Sync()
-	_ = f.Close()
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return Result{Name: "Disk seq R/W", Err: err.Error()}
+	}
+	if err := f.Close(); err != nil {
+		return Result{Name: "Disk seq R/W", Err: err.Error()}
+	}
+	write := perSecond(wBytes, time.Since(start), BytesPerSec)
+	if wBytes == 0 {
+		return Result{Name: "Disk seq R/W", Err: "no data written"}
+	}
 
 	rf, err := os.Open(path)
 	if err != nil {
 		return Result{Name: "Disk seq R/W", Err: err.Error()}
 	}
 	defer rf.Close()
+	// the file is whole chunks, so full-chunk reads line up and every one
+	// must match what was written; only time spent reading counts
 	var rBytes uint64
-	start = time.Now()
+	var readTime time.Duration
+	lat := NewHistogram()
 	buf := make([]byte, len(chunk))
-	for time.Since(start) < dur {
-		select {
-		case <-ctx.Done():
-			break
-		default:
-		}
-		n, err := rf.Read(buf)
-		if n > 0 {
-			rBytes += uint64(n)
+	for readTime < dur {
+		if ctx.Err() != nil {
+			return Result{Name: "Disk seq R/W", Err: "canceled"}
 		}
+		t0 := time.Now()
+		n, err := io.ReadFull(rf, buf)
+		d := time.Since(t0)
 		if err == io.EOF {
 			_, _ = rf.Seek(0, 0)
 			continue
@@ -82,9 +90,17 @@ func RunDiskSeq(ctx context.Context, dur time.Duration, path string) Result {
 		if err != nil {
 			return Result{Name: "Disk seq R/W", Err: err.Error()}
 		}
+		readTime += d
+		lat.Record(d)
+		rBytes += uint64(n)
+		if !bytes.Equal(buf, chunk) {
+			return Result{Name: "Disk seq R/W", Err: verifyErr("read-back chunk crc32c",
+				crcHex(crc32.Checksum(buf, castagnoli)), crcHex(crc32.Checksum(chunk, castagnoli))).Error()}
+		}
 	}
-	_ = os.Remove(path)
 
-	notes := "write " + humanBytes(wBytes) + "/s, read " + humanBytes(rBytes) + "/s"
-	return Result{Name: "Disk seq R/W", Duration: dur, Bytes: rBytes, Unit: "B/s", Notes: notes}
+	read := perSecond(rBytes, readTime, BytesPerSec)
+	return Result{Name: "Disk seq R/W", Duration: readTime, Bytes: rBytes, Throughput: read, Latency: lat,
+		Checksum: crcHex(crc32.Checksum(chunk, castagnoli)), Notes: "write " + write.String() + ", read " + read.String(),
+		Metrics: map[string]float64{"write_bytes_per_s": write.Value, "read_bytes_per_s": read.Value}}
 }
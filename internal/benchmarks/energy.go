@@ -0,0 +1,89 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Energy is the package energy measured around one test run. It covers
+// the whole run including setup, so Watts, not Joules, is what the
+// per-work figures are derived from.
+type Energy struct {
+	Joules  float64 `json:"joules"`
+	Seconds float64 `json:"seconds"` // wall time the energy was measured over
+	Watts   float64 `json:"watts"`   // average package power
+	Source  string  `json:"source"`
+
+	// Work per joule and joules per unit of work, in the base unit of
+	// the result's Throughput; 0 for latency tests.
+	PerJoule      float64 `json:"per_joule,omitempty"`
+	JoulesPerUnit float64 `json:"joules_per_unit,omitempty"`
+}
+
+// SetEnergy records joules used over wall and derives power and
+// efficiency from the result's throughput.
+func (r *Result) SetEnergy(joules float64, wall time.Duration, source string) {
+	if joules <= 0 || wall <= 0 {
+		return
+	}
+	e := &Energy{Joules: joules, Seconds: wall.Seconds(), Watts: joules / wall.Seconds(), Source: source}
+	if tp := r.Throughput; tp.Value > 0 && !tp.Unit.LowerIsBetter() {
+		e.PerJoule = tp.Value / e.Watts
+		e.JoulesPerUnit = e.Watts / tp.Value
+	}
+	r.Energy = e
+}
+
+// EfficiencyString formats work per joule, e.g. "85.20 MiB/J", with the
+// average power.
+func (r Result) EfficiencyString() string {
+	e := r.Energy
+	if e == nil {
+		return "—"
+	}
+	if e.PerJoule <= 0 {
+		return fmt.Sprintf("%.1f W", e.Watts)
+	}
+	per := strings.TrimSuffix(Throughput{Value: e.PerJoule, Unit: r.Throughput.Unit}.String(), "/s")
+	return fmt.Sprintf("%s/J @ %.1f W", per, e.Watts)
+}
+
+// EnergyPerUnitString formats joules per unit of work: per GiB for byte
+// rates, per single operation otherwise, e.g. "3.10 J/GiB", "42.0 µJ/hash".
+func (r Result) EnergyPerUnitString() string {
+	e := r.Energy
+	if e == nil || e.JoulesPerUnit <= 0 {
+		return "—"
+	}
+	if r.Throughput.Unit == BytesPerSec {
+		return fmt.Sprintf("%.2f J/GiB", e.JoulesPerUnit*(1<<30))
+	}
+	v, prefix := e.JoulesPerUnit, 0
+	prefixes := []string{"", "m", "µ", "n", "p"}
+	for v < 1 && prefix < len(prefixes)-1 {
+		v *= 1000
+		prefix++
+	}
+	return fmt.Sprintf("%.1f %sJ/%s", v, prefixes[prefix], strings.TrimSuffix(r.Throughput.Unit.String(), "/s"))
+}
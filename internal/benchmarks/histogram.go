@@ -0,0 +1,278 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/bits"
+	"time"
+)
+
+// histBits controls precision: each power-of-two range is split into
+// 2^(histBits-1) linear sub-buckets, so a bucket spans at most 1/64 of its
+// values. Percentile reports a bucket's upper bound and so reads up to
+// about 1.6% high.
+const (
+	histBits    = 7
+	histSub     = 1 << histBits
+	histHalf    = histSub / 2
+	histBuckets = (64-histBits)*histHalf + histSub
+)
+
+// Histogram is an HDR-style log-linear latency histogram (nanoseconds).
+// It is not safe for concurrent use: give each worker its own and Merge.
+type Histogram struct {
+	counts []uint64
+	total  uint64
+	min    int64
+	max    int64
+	sum    float64
+}
+
+// HistBucket is one non-empty bucket, bounds inclusive.
+type HistBucket struct {
+	Lower time.Duration
+	Upper time.Duration
+	Count uint64
+}
+
+func NewHistogram() *Histogram {
+	return &Histogram{counts: make([]uint64, histBuckets), min: math.MaxInt64}
+}
+
+func histIndex(v uint64) int {
+	if v < histSub {
+		return int(v)
+	}
+	shift := bits.Len64(v) - histBits
+	return shift*histHalf + int(v>>uint(shift))
+}
+
+func histBounds(idx int) (lo, hi uint64) {
+	if idx < histSub {
+		return uint64(idx), uint64(idx)
+	}
+	shift := idx/histHalf - 1
+	m := uint64(idx - shift*histHalf)
+	return m << uint(shift), (m+1)<<uint(shift) - 1
+}
+
+func (h *Histogram) Record(d time.Duration) { h.RecordValue(int64(d)) }
+
+func (h *Histogram) RecordValue(ns int64) {
+	if ns < 0 {
+		ns = 0
+	}
+	h.counts[histIndex(uint64(ns))]++
+	h.total++
+	h.sum += float64(ns)
+	if ns < h.min {
+		h.min = ns
+	}
+	if ns > h.max {
+		h.max = ns
+	}
+}
+
+// Merge adds all samples of o into h.
+func (h *Histogram) Merge(o *Histogram) {
+	if o == nil || o.total == 0 {
+		return
+	}
+	for i, c := range o.counts {
+		h.counts[i] += c
+	}
+	h.total += o.total
+	h.sum += o.sum
+	if o.min < h.min {
+		h.min = o.min
+	}
+	if o.max > h.max {
+		h.max = o.max
+	}
+}
+
+func (h *Histogram) Count() uint64 { return h.total }
+
+func (h *Histogram) Min() time.Duration {
+	if h.total == 0 {
+		return 0
+	}
+	return time.Duration(h.min)
+}
+
+func (h *Histogram) Max() time.Duration { return time.Duration(h.max) }
+
+func (h *Histogram) Mean() time.Duration {
+	if h.total == 0 {
+		return 0
+	}
+	return time.Duration(h.sum / float64(h.total))
+}
+
+// Percentile returns the highest value equivalent to the p-th percentile
+// (0 < p <= 100), clamped to the recorded range.
+func (h *Histogram) Percentile(p float64) time.Duration {
+	if h.total == 0 {
+		return 0
+	}
+	if p <= 0 {
+		return h.Min()
+	}
+	rank := uint64(math.Ceil(p / 100 * float64(h.total)))
+	if rank < 1 {
+		rank = 1
+	}
+	var cum uint64
+	for i, c := range h.counts {
+		cum += c
+		if cum >= rank {
+			_, hi := histBounds(i)
+			v := int64(hi)
+			if v > h.max {
+				v = h.max
+			}
+			if v < h.min {
+				v = h.min
+			}
+			return time.Duration(v)
+		}
+	}
+	return time.Duration(h.max)
+}
+
+// Buckets returns the non-empty buckets in ascending order.
+func (h *Histogram) Buckets() []HistBucket {
+	var out []HistBucket
+	for i, c := range h.counts {
+		if c == 0 {
+			continue
+		}
+		lo, hi := histBounds(i)
+		out = append(out, HistBucket{Lower: time.Duration(lo), Upper: time.Duration(hi), Count: c})
+	}
+	return out
+}
+
+// Octaves folds the buckets into power-of-two ranges, which is what the
+// GUI histogram view plots.
+func (h *Histogram) Octaves() []HistBucket {
+	var out []HistBucket
+	for _, b := range h.Buckets() {
+		lo := uint64(0)
+		if b.Lower > 0 {
+			lo = 1 << uint(bits.Len64(uint64(b.Lower))-1)
+		}
+		if n := len(out); n > 0 && uint64(out[n-1].Lower) == lo {
+			out[n-1].Count += b.Count
+			continue
+		}
+		hi := uint64(0)
+		if lo > 0 {
+			hi = lo<<1 - 1
+		}
+		out = append(out, HistBucket{Lower: time.Duration(lo), Upper: time.Duration(hi), Count: b.Count})
+	}
+	return out
+}
+
+// histJSON is the export form: the non-empty buckets are packed as
+// uvarint (index gap, count) pairs and base64-encoded.
+type histJSON struct {
+	Precision int     `json:"precision"`
+	Count     uint64  `json:"count"`
+	MinNs     int64   `json:"min_ns"`
+	MaxNs     int64   `json:"max_ns"`
+	MeanNs    float64 `json:"mean_ns"`
+	P50Ns     int64   `json:"p50_ns"`
+	P99Ns     int64   `json:"p99_ns"`
+	P999Ns    int64   `json:"p999_ns"`
+	Buckets   string  `json:"buckets"`
+}
+
+func (h *Histogram) MarshalJSON() ([]byte, error) {
+	var packed []byte
+	prev := -1
+	for i, c := range h.counts {
+		if c == 0 {
+			continue
+		}
+		packed = binary.AppendUvarint(packed, uint64(i-prev))
+		packed = binary.AppendUvarint(packed, c)
+		prev = i
+	}
+	hj := histJSON{
+		Precision: histBits,
+		Count:     h.total,
+		MinNs:     int64(h.Min()),
+		MaxNs:     h.max,
+		MeanNs:    float64(h.Mean()),
+		P50Ns:     int64(h.Percentile(50)),
+		P99Ns:     int64(h.Percentile(99)),
+		P999Ns:    int64(h.Percentile(99.9)),
+		Buckets:   base64.StdEncoding.EncodeToString(packed),
+	}
+	return json.Marshal(hj)
+}
+
+func (h *Histogram) UnmarshalJSON(b []byte) error {
+	var hj histJSON
+	if err := json.Unmarshal(b, &hj); err != nil {
+		return err
+	}
+	if hj.Precision != histBits {
+		return errors.New("histogram: unsupported precision")
+	}
+	packed, err := base64.StdEncoding.DecodeString(hj.Buckets)
+	if err != nil {
+		return err
+	}
+	*h = *NewHistogram()
+	idx := -1
+	for len(packed) > 0 {
+		gap, n := binary.Uvarint(packed)
+		if n <= 0 {
+			return errors.New("histogram: corrupt buckets")
+		}
+		packed = packed[n:]
+		c, n := binary.Uvarint(packed)
+		if n <= 0 {
+			return errors.New("histogram: corrupt buckets")
+		}
+		packed = packed[n:]
+		idx += int(gap)
+		if idx < 0 || idx >= histBuckets {
+			return errors.New("histogram: bucket out of range")
+		}
+		h.counts[idx] = c
+		h.total += c
+	}
+	if h.total > 0 {
+		h.min = hj.MinNs
+		h.max = hj.MaxNs
+		h.sum = hj.MeanNs * float64(h.total)
+	}
+	return nil
+}
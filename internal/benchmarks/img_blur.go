@@ -22,9 +22,9 @@ package benchmarks
 
 import (
 	"context"
+	"fmt"
 	"math"
 	"runtime"
-	"sync"
 	"time"
 )
 
@@ -55,85 +55,79 @@ func gaussianKernel(r int, sigma float64) []float32 {
 	return k
 }
 
-func blur1D(dst, src []float32, w, h, r int, k []float32, horizontal bool) {
-	if horizontal {
-		for y := 0; y < h; y++ {
-			o := y * w
-			for x := 0; x < w; x++ {
-				var acc float32
-				for i := -r; i <= r; i++ {
-					xi := x + i
-					if xi < 0 {
-						xi = 0
-					} else if xi >= w {
-						xi = w - 1
-					}
-					acc += src[o+xi] * k[i+r]
-				}
-				dst[o+x] = acc
+// blurH convolves rows [lo,hi) horizontally; each row only reads itself.
+func blurH(dst, src []float32, w, lo, hi, r int, k []float32) {
+	for y := lo; y < hi; y++ {
+		o := y * w
+		for x := 0; x < w; x++ {
+			var acc float32
+			for i := -r; i <= r; i++ {
+				acc += src[o+clampIdx(x+i, w)] * k[i+r]
 			}
+			dst[o+x] = acc
 		}
-	} else {
-		for y := 0; y < h; y++ {
-			for x := 0; x < w; x++ {
-				var acc float32
-				for i := -r; i <= r; i++ {
-					yi := y + i
-					if yi < 0 {
-						yi = 0
-					} else if yi >= h {
-						yi = h - 1
-					}
-					acc += src[yi*w+x] * k[i+r]
-				}
-				dst[y*w+x] = acc
+	}
+}
+
+// blurV convolves rows [lo,hi) vertically, reading up to r rows on
+// either side from the full frame src, clamped at the frame border.
+func blurV(dst, src []float32, w, h, lo, hi, r int, k []float32) {
+	for y := lo; y < hi; y++ {
+		for x := 0; x < w; x++ {
+			var acc float32
+			for i := -r; i <= r; i++ {
+				acc += src[clampIdx(y+i, h)*w+x] * k[i+r]
 			}
+			dst[y*w+x] = acc
 		}
 	}
 }
 
+// blurChecksum folds the bit patterns of an output frame into one value.
+func blurChecksum(px []float32) uint64 {
+	h := newFNV()
+	for _, v := range px {
+		h.mix(uint64(math.Float32bits(v)))
+	}
+	return uint64(h)
+}
+
+// RunImageBlur blurs whole 1080p frames. Each pass is split into row
+// bands across threads, and the vertical pass starts only when the
+// horizontal one has finished the whole frame, so band edges see their
+// real neighbours and every frame matches the single-threaded reference.
 func RunImageBlur(ctx context.Context, dur time.Duration, threads int) Result {
 	if threads <= 0 {
 		threads = runtime.NumCPU()
 	}
-	ctx, cancel := context.WithTimeout(ctx, dur)
-	defer cancel()
-
 	img := makeNoise(1920, 1080)
 	r := 5
 	k := gaussianKernel(r, 2.0)
-	workPerThread := (img.H + threads - 1) / threads
+	w, h := img.W, img.H
+
+	ref, tmp := make([]float32, w*h), make([]float32, w*h)
+	blurH(tmp, img.Pix, w, 0, h, r, k)
+	blurV(ref, tmp, w, h, 0, h, r, k)
+	want := blurChecksum(ref)
 
-	var wg sync.WaitGroup
-	var px uint64
-	start := time.Now()
-	for y0 := 0; y0 < img.H; y0 += workPerThread {
-		y1 := y0 + workPerThread
-		if y1 > img.H {
-			y1 = img.H
+	dst := make([]float32, w*h)
+	n := 0
+	frames, elapsed, lat, err := runFrames(ctx, dur, func() error {
+		parallelFor(ctx, threads, h, 16, func(lo, hi int) { blurH(tmp, img.Pix, w, lo, hi, r, k) })
+		parallelFor(ctx, threads, h, 16, func(lo, hi int) { blurV(dst, tmp, w, h, lo, hi, r, k) })
+		n++
+		if !verifyIteration(n) {
+			return nil
 		}
-		wg.Add(1)
-		go func(y0, y1 int) {
-			defer wg.Done()
-			src := img.Pix[y0*img.W : y1*img.W]
-			tmp := make([]float32, len(src))
-			dst := make([]float32, len(src))
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				default:
-					blur1D(tmp, src, img.W, y1-y0, r, k, true)
-					blur1D(dst, tmp, img.W, y1-y0, r, k, false)
-					px += uint64((y1 - y0) * img.W)
-				}
-			}
-		}(y0, y1)
-	}
-	wg.Wait()
-	elapsed := time.Since(start)
-	if elapsed == 0 {
-		elapsed = dur
+		if got := blurChecksum(dst); got != want {
+			return verifyErr("frame checksum", fmt.Sprintf("%016x", got), fmt.Sprintf("%016x", want))
+		}
+		return nil
+	})
+	if err != nil {
+		return Result{Name: "Gaussian Blur 1080p", Threads: threads, Err: err.Error()}
 	}
-	return Result{Name: "Gaussian Blur 1080p", Threads: threads, Duration: dur, Ops: px / uint64(max64(1, int64(elapsed.Seconds()))), Unit: "px/s"}
+	px := frames * uint64(w*h)
+	return Result{Name: "Gaussian Blur 1080p", Threads: threads, Duration: elapsed, Ops: px, Throughput: perSecond(px, elapsed, PixelsPerSec), Latency: lat,
+		Checksum: fmt.Sprintf("fnv64:%016x", want), Notes: fmt.Sprintf("%d frames, radius %d", frames, r)}
 }
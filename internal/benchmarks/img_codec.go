@@ -0,0 +1,181 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"runtime"
+	"time"
+)
+
+// ImageCodec selects the format used by RunImageCodec.
+type ImageCodec int
+
+const (
+	PNG ImageCodec = iota
+	JPEG
+)
+
+func (c ImageCodec) String() string {
+	if c == JPEG {
+		return "JPEG"
+	}
+	return "PNG"
+}
+
+const jpegQuality = 90
+
+func (c ImageCodec) encode(buf *bytes.Buffer, img image.Image) error {
+	if c == JPEG {
+		return jpeg.Encode(buf, img, &jpeg.Options{Quality: jpegQuality})
+	}
+	return png.Encode(buf, img)
+}
+
+func (c ImageCodec) decode(b []byte) (image.Image, error) {
+	if c == JPEG {
+		return jpeg.Decode(bytes.NewReader(b))
+	}
+	return png.Decode(bytes.NewReader(b))
+}
+
+// decodedChecksum hashes the pixel planes of what the standard decoders
+// return for our images in place.
+func decodedChecksum(img image.Image) (uint64, error) {
+	switch m := img.(type) {
+	case *image.RGBA:
+		return pixChecksum(m.Pix), nil
+	case *image.YCbCr:
+		return ycbcrChecksum(m), nil
+	}
+	return 0, fmt.Errorf("unexpected decoded image type %T", img)
+}
+
+// psnr compares a decoded image with the RGB of the source.
+func psnr(src *image.RGBA, img image.Image) float64 {
+	var se float64
+	b := src.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r0, g0, b0, _ := src.At(x, y).RGBA()
+			r1, g1, b1, _ := img.At(x, y).RGBA()
+			for _, d := range []float64{float64(r0>>8) - float64(r1>>8), float64(g0>>8) - float64(g1>>8), float64(b0>>8) - float64(b1>>8)} {
+				se += d * d
+			}
+		}
+	}
+	mse := se / float64(3*b.Dx()*b.Dy())
+	if mse == 0 {
+		return math.Inf(1)
+	}
+	return 10 * math.Log10(255*255/mse)
+}
+
+// RunImageCodec encodes the test card (or, with decode set, decodes its
+// encoded form) with the standard image packages, one image per worker
+// at a time. PNG must round-trip exactly; JPEG must stay above 30 dB.
+func RunImageCodec(ctx context.Context, dur time.Duration, threads int, res ImageRes, codec ImageCodec, decode bool) Result {
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	name := codec.String() + " Encode"
+	if decode {
+		name = codec.String() + " Decode"
+	}
+	src := genRGBA(res.W, res.H)
+	var enc bytes.Buffer
+	if err := codec.encode(&enc, src); err != nil {
+		return Result{Name: name, Threads: threads, Err: err.Error()}
+	}
+	ref, err := codec.decode(enc.Bytes())
+	if err != nil {
+		return Result{Name: name, Threads: threads, Err: err.Error()}
+	}
+	wantDec, err := decodedChecksum(ref)
+	if err != nil {
+		return Result{Name: name, Threads: threads, Err: err.Error()}
+	}
+	if m, ok := ref.(*image.RGBA); codec == PNG && (!ok || !bytes.Equal(m.Pix, src.Pix)) {
+		return Result{Name: name, Threads: threads, Err: "reference: PNG round trip is not lossless"}
+	}
+	quality := psnr(src, ref)
+	if quality < 30 {
+		return Result{Name: name, Threads: threads, Err: fmt.Sprintf("reference: JPEG round trip PSNR %.1f dB", quality)}
+	}
+	wantEnc := crc32.Checksum(enc.Bytes(), castagnoli)
+	px := uint64(res.W * res.H)
+
+	pixels, elapsed, lat, err := runWorkers(ctx, dur, threads, func() workFn {
+		var buf bytes.Buffer
+		n := 0
+		return func() (uint64, error) {
+			n++
+			if decode {
+				img, err := codec.decode(enc.Bytes())
+				if err != nil {
+					return 0, err
+				}
+				if !verifyIteration(n) {
+					return px, nil
+				}
+				got, err := decodedChecksum(img)
+				if err != nil {
+					return 0, err
+				}
+				if got != wantDec {
+					return 0, verifyErr("decoded pixels checksum", fmt.Sprintf("%016x", got), fmt.Sprintf("%016x", wantDec))
+				}
+				return px, nil
+			}
+			buf.Reset()
+			if err := codec.encode(&buf, src); err != nil {
+				return 0, err
+			}
+			if !verifyIteration(n) {
+				return px, nil
+			}
+			if got := crc32.Checksum(buf.Bytes(), castagnoli); got != wantEnc {
+				return 0, verifyErr("encoded crc32c", crcHex(got), crcHex(wantEnc))
+			}
+			return px, nil
+		}
+	})
+	if err != nil {
+		return Result{Name: name, Threads: threads, Err: err.Error()}
+	}
+	notes := fmt.Sprintf("%s, %s encoded", res, humanBytes(uint64(enc.Len())))
+	if codec == JPEG {
+		notes += fmt.Sprintf(", q=%d, %.1f dB", jpegQuality, quality)
+	}
+	sum := crcHex(wantEnc)
+	if decode {
+		sum = fmt.Sprintf("fnv64:%016x", wantDec)
+	}
+	return Result{Name: name, Threads: threads, Duration: elapsed, Ops: pixels, Throughput: perSecond(pixels, elapsed, PixelsPerSec), Latency: lat,
+		Checksum: sum, Notes: notes}
+}
@@ -0,0 +1,113 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"runtime"
+	"time"
+)
+
+// toYCbCr420 converts rows [lo,hi) of src (lo and hi even) into dst with
+// the standard JFIF coefficients, chroma from the mean of each 2x2 block.
+func toYCbCr420(src *image.RGBA, dst *image.YCbCr, lo, hi int) {
+	w := src.Bounds().Dx()
+	for y := lo; y < hi; y += 2 {
+		for x := 0; x < w; x += 2 {
+			var sr, sg, sb int
+			for dy := 0; dy < 2; dy++ {
+				for dx := 0; dx < 2; dx++ {
+					o := (y+dy)*src.Stride + (x+dx)*4
+					r, g, b := src.Pix[o], src.Pix[o+1], src.Pix[o+2]
+					yy, _, _ := color.RGBToYCbCr(r, g, b)
+					dst.Y[(y+dy)*dst.YStride+x+dx] = yy
+					sr, sg, sb = sr+int(r), sg+int(g), sb+int(b)
+				}
+			}
+			_, cb, cr := color.RGBToYCbCr(uint8((sr+2)/4), uint8((sg+2)/4), uint8((sb+2)/4))
+			ci := dst.COffset(x, y)
+			dst.Cb[ci], dst.Cr[ci] = cb, cr
+		}
+	}
+}
+
+func ycbcrChecksum(m *image.YCbCr) uint64 {
+	h := newFNV()
+	h.mix(pixChecksum(m.Y))
+	h.mix(pixChecksum(m.Cb))
+	h.mix(pixChecksum(m.Cr))
+	return uint64(h)
+}
+
+// RunImageYCbCr converts the test card from RGBA to YCbCr 4:2:0, the
+// first step of every video and JPEG encoder, with row pairs split
+// between threads.
+func RunImageYCbCr(ctx context.Context, dur time.Duration, threads int, res ImageRes) Result {
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	const name = "YCbCr Convert"
+	src := genRGBA(res.W, res.H)
+	rect := src.Bounds()
+	ref := image.NewYCbCr(rect, image.YCbCrSubsampleRatio420)
+	toYCbCr420(src, ref, 0, res.H)
+
+	// converting back must land near the source; chroma subsampling
+	// costs a little at the disc edges, nothing like a swapped plane
+	var diff, n float64
+	for y := 0; y < res.H; y += 7 {
+		for x := 0; x < res.W; x += 7 {
+			r0, g0, b0, _ := src.At(x, y).RGBA()
+			r1, g1, b1, _ := ref.At(x, y).RGBA()
+			for _, d := range []int{int(r0>>8) - int(r1>>8), int(g0>>8) - int(g1>>8), int(b0>>8) - int(b1>>8)} {
+				diff += float64(max(d, -d))
+				n++
+			}
+		}
+	}
+	if diff/n > 4 {
+		return Result{Name: name, Threads: threads, Err: fmt.Sprintf("reference: round trip mean error %.1f", diff/n)}
+	}
+	want := ycbcrChecksum(ref)
+
+	dst := image.NewYCbCr(rect, image.YCbCrSubsampleRatio420)
+	frame := 0
+	frames, elapsed, lat, err := runFrames(ctx, dur, func() error {
+		parallelFor(ctx, threads, res.H/2, 8, func(lo, hi int) { toYCbCr420(src, dst, lo*2, hi*2) })
+		frame++
+		if !verifyIteration(frame) {
+			return nil
+		}
+		if got := ycbcrChecksum(dst); got != want {
+			return verifyErr("YCbCr planes checksum", fmt.Sprintf("%016x", got), fmt.Sprintf("%016x", want))
+		}
+		return nil
+	})
+	if err != nil {
+		return Result{Name: name, Threads: threads, Err: err.Error()}
+	}
+	px := frames * uint64(res.W*res.H)
+	return Result{Name: name, Threads: threads, Duration: elapsed, Ops: px, Throughput: perSecond(px, elapsed, PixelsPerSec), Latency: lat,
+		Checksum: fmt.Sprintf("fnv64:%016x", want), Notes: fmt.Sprintf("%s, RGBA -> 4:2:0, mean round-trip error %.2f", res, diff/n)}
+}
@@ -0,0 +1,131 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+	"time"
+)
+
+// ImageRes is a frame size offered for the image tests.
+type ImageRes struct {
+	Name string
+	W, H int
+}
+
+var ImageResolutions = []ImageRes{
+	{"720p", 1280, 720},
+	{"1080p", 1920, 1080},
+	{"4K", 3840, 2160},
+}
+
+// LookupImageRes returns the resolution called name, 1080p if unknown.
+func LookupImageRes(name string) ImageRes {
+	for _, r := range ImageResolutions {
+		if strings.EqualFold(r.Name, name) {
+			return r
+		}
+	}
+	return ImageResolutions[1]
+}
+
+func (r ImageRes) String() string { return fmt.Sprintf("%s (%dx%d)", r.Name, r.W, r.H) }
+
+// genRGBA draws a deterministic test card: smooth gradients (what codecs
+// and resamplers mostly see), hard-edged discs (for Sobel and ringing)
+// and a band of noise (texture that resists compression).
+func genRGBA(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	type disc struct{ x, y, r float64 }
+	discs := []disc{{0.25, 0.3, 0.12}, {0.6, 0.55, 0.2}, {0.85, 0.2, 0.08}}
+	var seed uint32 = 2463534242
+	for y := 0; y < h; y++ {
+		fy := float64(y) / float64(h)
+		for x := 0; x < w; x++ {
+			fx := float64(x) / float64(w)
+			r := 255 * fx
+			g := 255 * fy
+			b := 127.5 + 127.5*math.Sin(6*math.Pi*fx*fy)
+			for i, d := range discs {
+				dx, dy := (fx-d.x)*float64(w)/float64(h), fy-d.y
+				if dx*dx+dy*dy < d.r*d.r {
+					r, g, b = float64(80*i), 255-float64(60*i), 40
+				}
+			}
+			if fy > 0.8 {
+				seed ^= seed << 13
+				seed ^= seed >> 17
+				seed ^= seed << 5
+				n := float64(seed&63) - 32
+				r, g, b = r+n, g+n, b+n
+			}
+			img.SetRGBA(x, y, color.RGBA{clamp8(r), clamp8(g), clamp8(b), 255})
+		}
+	}
+	return img
+}
+
+func clamp8(v float64) uint8 {
+	switch {
+	case v <= 0:
+		return 0
+	case v >= 255:
+		return 255
+	}
+	return uint8(v + 0.5)
+}
+
+func pixChecksum(pix []uint8) uint64 {
+	h := newFNV()
+	for i := 0; i+8 <= len(pix); i += 8 {
+		h.mix(uint64(pix[i]) | uint64(pix[i+1])<<8 | uint64(pix[i+2])<<16 | uint64(pix[i+3])<<24 |
+			uint64(pix[i+4])<<32 | uint64(pix[i+5])<<40 | uint64(pix[i+6])<<48 | uint64(pix[i+7])<<56)
+	}
+	for _, v := range pix[len(pix)/8*8:] {
+		h.mix(uint64(v))
+	}
+	return uint64(h)
+}
+
+// runFrames calls frame until dur elapses; frame processes one whole
+// image, splitting it between threads itself.
+func runFrames(ctx context.Context, dur time.Duration, frame func() error) (uint64, time.Duration, *Histogram, error) {
+	ctx, cancel := context.WithTimeout(ctx, dur)
+	defer cancel()
+	lat := NewHistogram()
+	var frames uint64
+	start := time.Now()
+	for ctx.Err() == nil {
+		t0 := time.Now()
+		err := frame()
+		lat.Record(time.Since(t0))
+		if err != nil {
+			return frames, time.Since(start), lat, err
+		}
+		frames++
+	}
+	return frames, time.Since(start), lat, nil
+}
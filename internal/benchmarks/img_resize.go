@@ -0,0 +1,207 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"math"
+	"runtime"
+	"time"
+)
+
+// ResizeFilter selects the resampling kernel used by RunImageResize.
+type ResizeFilter int
+
+const (
+	Bilinear ResizeFilter = iota
+	Lanczos3
+)
+
+func (f ResizeFilter) String() string {
+	if f == Lanczos3 {
+		return "Lanczos"
+	}
+	return "Bilinear"
+}
+
+func (f ResizeFilter) radius() float64 {
+	if f == Lanczos3 {
+		return 3
+	}
+	return 1
+}
+
+func (f ResizeFilter) at(x float64) float64 {
+	x = math.Abs(x)
+	if f == Bilinear {
+		return max(0, 1-x)
+	}
+	if x == 0 {
+		return 1
+	}
+	if x >= 3 {
+		return 0
+	}
+	px := math.Pi * x
+	return 3 * math.Sin(px) * math.Sin(px/3) / (px * px)
+}
+
+// taps are the source indices and normalised weights of one output
+// coordinate.
+type taps struct {
+	first   int
+	weights []float32
+}
+
+// resizeTaps precomputes the contributions for scaling srcN to dstN,
+// widening the kernel when downscaling so it also low-passes.
+func resizeTaps(f ResizeFilter, srcN, dstN int) []taps {
+	scale := float64(srcN) / float64(dstN)
+	support := f.radius() * max(scale, 1)
+	out := make([]taps, dstN)
+	for i := range out {
+		center := (float64(i)+0.5)*scale - 0.5
+		lo, hi := int(math.Ceil(center-support)), int(math.Floor(center+support))
+		w := make([]float32, hi-lo+1)
+		var sum float64
+		for j := lo; j <= hi; j++ {
+			v := f.at((float64(j) - center) / max(scale, 1))
+			w[j-lo] = float32(v)
+			sum += v
+		}
+		for j := range w {
+			w[j] = float32(float64(w[j]) / sum)
+		}
+		out[i] = taps{first: lo, weights: w}
+	}
+	return out
+}
+
+type resizer struct {
+	src        *image.RGBA
+	dst        *image.RGBA
+	tmp        []float32 // dstW x srcH x 4, after the horizontal pass
+	hTaps      []taps
+	vTaps      []taps
+	srcW, srcH int
+	dstW, dstH int
+}
+
+func newResizer(f ResizeFilter, src *image.RGBA, dstW, dstH int) *resizer {
+	b := src.Bounds()
+	return &resizer{
+		src: src, dst: image.NewRGBA(image.Rect(0, 0, dstW, dstH)),
+		tmp:   make([]float32, dstW*b.Dy()*4),
+		hTaps: resizeTaps(f, b.Dx(), dstW), vTaps: resizeTaps(f, b.Dy(), dstH),
+		srcW: b.Dx(), srcH: b.Dy(), dstW: dstW, dstH: dstH,
+	}
+}
+
+func clampIdx(i, n int) int { return min(max(i, 0), n-1) }
+
+func (r *resizer) horizontal(lo, hi int) {
+	for y := lo; y < hi; y++ {
+		row := r.src.Pix[y*r.src.Stride:]
+		out := r.tmp[y*r.dstW*4:]
+		for x, t := range r.hTaps {
+			var cr, cg, cb, ca float32
+			for k, w := range t.weights {
+				o := clampIdx(t.first+k, r.srcW) * 4
+				cr += w * float32(row[o])
+				cg += w * float32(row[o+1])
+				cb += w * float32(row[o+2])
+				ca += w * float32(row[o+3])
+			}
+			out[x*4], out[x*4+1], out[x*4+2], out[x*4+3] = cr, cg, cb, ca
+		}
+	}
+}
+
+func (r *resizer) vertical(lo, hi int) {
+	for y := lo; y < hi; y++ {
+		t := r.vTaps[y]
+		out := r.dst.Pix[y*r.dst.Stride:]
+		for x := 0; x < r.dstW*4; x++ {
+			var c float32
+			for k, w := range t.weights {
+				c += w * r.tmp[clampIdx(t.first+k, r.srcH)*r.dstW*4+x]
+			}
+			out[x] = clamp8(float64(c))
+		}
+	}
+}
+
+// frame resizes the whole image; the vertical pass starts only once
+// every intermediate row exists, so bands never miss their neighbours.
+func (r *resizer) frame(ctx context.Context, threads int) {
+	parallelFor(ctx, threads, r.srcH, 16, r.horizontal)
+	parallelFor(ctx, threads, r.dstH, 16, r.vertical)
+}
+
+// RunImageResize halves the test card in each dimension with a
+// separable two-pass filter, rows split between threads.
+func RunImageResize(ctx context.Context, dur time.Duration, threads int, res ImageRes, filter ResizeFilter) Result {
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	name := "Resize " + filter.String()
+	dstW, dstH := res.W/2, res.H/2
+
+	// a flat image must stay flat, whatever the kernel's ringing
+	flat := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	for i := range flat.Pix {
+		flat.Pix[i] = 200
+	}
+	fr := newResizer(filter, flat, 32, 32)
+	fr.frame(ctx, 1)
+	for _, v := range fr.dst.Pix {
+		if v != 200 {
+			return Result{Name: name, Threads: threads, Err: fmt.Sprintf("reference: flat image resized to %d", v)}
+		}
+	}
+
+	src := genRGBA(res.W, res.H)
+	ref := newResizer(filter, src, dstW, dstH)
+	ref.frame(ctx, 1)
+	want := pixChecksum(ref.dst.Pix)
+
+	rz := newResizer(filter, src, dstW, dstH)
+	n := 0
+	frames, elapsed, lat, err := runFrames(ctx, dur, func() error {
+		rz.frame(ctx, threads)
+		n++
+		if !verifyIteration(n) {
+			return nil
+		}
+		if got := pixChecksum(rz.dst.Pix); got != want {
+			return verifyErr("resized image checksum", fmt.Sprintf("%016x", got), fmt.Sprintf("%016x", want))
+		}
+		return nil
+	})
+	if err != nil {
+		return Result{Name: name, Threads: threads, Err: err.Error()}
+	}
+	px := frames * uint64(res.W*res.H)
+	return Result{Name: name, Threads: threads, Duration: elapsed, Ops: px, Throughput: perSecond(px, elapsed, PixelsPerSec), Latency: lat,
+		Checksum: fmt.Sprintf("fnv64:%016x", want), Notes: fmt.Sprintf("%s -> %dx%d, source px", res, dstW, dstH)}
+}
@@ -0,0 +1,88 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"math"
+	"runtime"
+	"time"
+)
+
+// sobelRows writes the gradient magnitude of rows [lo,hi) of src into
+// dst, reading neighbouring rows straight from the full source image and
+// clamping at the frame border.
+func sobelRows(src, dst *image.Gray, lo, hi int) {
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	at := func(x, y int) int { return int(src.Pix[clampIdx(y, h)*src.Stride+clampIdx(x, w)]) }
+	for y := lo; y < hi; y++ {
+		for x := 0; x < w; x++ {
+			gx := at(x+1, y-1) + 2*at(x+1, y) + at(x+1, y+1) - at(x-1, y-1) - 2*at(x-1, y) - at(x-1, y+1)
+			gy := at(x-1, y+1) + 2*at(x, y+1) + at(x+1, y+1) - at(x-1, y-1) - 2*at(x, y-1) - at(x+1, y-1)
+			dst.Pix[y*dst.Stride+x] = clamp8(math.Sqrt(float64(gx*gx + gy*gy)))
+		}
+	}
+}
+
+// RunImageSobel runs a 3x3 Sobel edge filter over the luma of the test
+// card, rows split between threads.
+func RunImageSobel(ctx context.Context, dur time.Duration, threads int, res ImageRes) Result {
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	const name = "Sobel"
+	rgba := genRGBA(res.W, res.H)
+	src := image.NewGray(rgba.Bounds())
+	for y := 0; y < res.H; y++ {
+		for x := 0; x < res.W; x++ {
+			src.Set(x, y, rgba.At(x, y))
+		}
+	}
+	ref := image.NewGray(src.Bounds())
+	sobelRows(src, ref, 0, res.H)
+	// the flat top-left corner has no edges; the disc outlines do
+	if ref.Pix[ref.Stride+1] > 8 {
+		return Result{Name: name, Threads: threads, Err: "reference: edge response on a flat area"}
+	}
+	want := pixChecksum(ref.Pix)
+
+	dst := image.NewGray(src.Bounds())
+	n := 0
+	frames, elapsed, lat, err := runFrames(ctx, dur, func() error {
+		parallelFor(ctx, threads, res.H, 16, func(lo, hi int) { sobelRows(src, dst, lo, hi) })
+		n++
+		if !verifyIteration(n) {
+			return nil
+		}
+		if got := pixChecksum(dst.Pix); got != want {
+			return verifyErr("edge map checksum", fmt.Sprintf("%016x", got), fmt.Sprintf("%016x", want))
+		}
+		return nil
+	})
+	if err != nil {
+		return Result{Name: name, Threads: threads, Err: err.Error()}
+	}
+	px := frames * uint64(res.W*res.H)
+	return Result{Name: name, Threads: threads, Duration: elapsed, Ops: px, Throughput: perSecond(px, elapsed, PixelsPerSec), Latency: lat,
+		Checksum: fmt.Sprintf("fnv64:%016x", want), Notes: res.String()}
+}
@@ -21,9 +21,10 @@
 package benchmarks
 
 import (
+	"bytes"
 	"context"
+	"hash/crc32"
 	"runtime"
-	"sync"
 	"time"
 )
 
@@ -31,34 +32,35 @@ func RunMemCopy(ctx context.Context, dur time.Duration, threads int) Result {
 	if threads <= 0 {
 		threads = runtime.NumCPU()
 	}
-	ctx, cancel := context.WithTimeout(ctx, dur)
-	defer cancel()
-
-	var wg sync.WaitGroup
-	var bytes uint64
 	bufSize := 8 * 1024 * 1024
+	pattern := make([]byte, bufSize)
+	for i := range pattern {
+		pattern[i] = byte(i*7 + i>>12)
+	}
 
-	done := make(chan struct{})
-	for i := 0; i < threads; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			src := make([]byte, bufSize)
-			dst := make([]byte, bufSize)
-			var local uint64
-			for {
-				select {
-				case <-ctx.Done():
-					bytes += local
-					return
-				default:
-					n := copy(dst, src)
-					local += uint64(n)
-				}
+	total, elapsed, _, err := runWorkers(ctx, dur, threads, func() workFn {
+		src := make([]byte, bufSize)
+		dst := make([]byte, bufSize)
+		copy(src, pattern)
+		n := 0
+		return func() (uint64, error) {
+			// a sampled copy goes into a cleared dst, so a copy that
+			// silently did nothing is caught
+			n++
+			check := verifyIteration(n)
+			if check {
+				clear(dst)
+			}
+			c := copy(dst, src)
+			if check && !bytes.Equal(dst, pattern) {
+				return 0, verifyErr("copied buffer crc32c", crcHex(crc32.Checksum(dst, castagnoli)), crcHex(crc32.Checksum(pattern, castagnoli)))
 			}
-		}()
+			return uint64(c), nil
+		}
+	})
+	if err != nil {
+		return Result{Name: "Memory copy", Threads: threads, Err: err.Error()}
 	}
-	go func() { wg.Wait(); close(done) }()
-	<-done
-	return Result{Name: "Memory copy", Threads: threads, Duration: dur, Bytes: bytes, Unit: "B/s"}
+	return Result{Name: "Memory copy", Threads: threads, Duration: elapsed, Bytes: total, Throughput: perSecond(total, elapsed, BytesPerSec),
+		Checksum: crcHex(crc32.Checksum(pattern, castagnoli))}
 }
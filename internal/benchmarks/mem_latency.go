@@ -0,0 +1,167 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"time"
+)
+
+const cacheLine = 64
+
+// memLatSink keeps the final chase position alive so the loop can't be
+// optimized away.
+var memLatSink uint64
+
+// MaxWorkingSet picks the largest working set for RunMemLatency: a quarter
+// of physical RAM rounded down to a power of two, between 64 MiB and 4 GiB.
+func MaxWorkingSet(totalRAM uint64) uint64 {
+	const lo, hi = 64 << 20, 4 << 30
+	ws := totalRAM / 4
+	if ws < lo {
+		return lo
+	}
+	if ws > hi {
+		ws = hi
+	}
+	return 1 << (bits.Len64(ws) - 1)
+}
+
+// RunMemLatency measures load-to-use latency by chasing a random cyclic
+// chain of cache-line sized nodes, for working sets doubling from 4 KiB up
+// to maxBytes. The curve goes to Series; the headline value is the latency
+// at the largest size, i.e. DRAM. Building the large chains takes longer
+// than chasing them, so it counts against dur: each size chases for an
+// even share of the time left.
+func RunMemLatency(ctx context.Context, dur time.Duration, maxBytes uint64) Result {
+	if maxBytes < 4096 {
+		maxBytes = 1 << 30
+	}
+	start := time.Now()
+	var sizes []uint64
+	for s := uint64(4096); s <= maxBytes; s <<= 1 {
+		sizes = append(sizes, s)
+	}
+
+	buf := make([]uint64, sizes[len(sizes)-1]/8)
+	rng := rand.New(rand.NewSource(7))
+	series := &Series{XLabel: "Working set", YLabel: "ns/access", LogX: true, XBytes: true}
+	metrics := map[string]float64{}
+
+	for i, size := range sizes {
+		ws := buf[:size/8]
+		if linkChain(ctx, ws, rng) != nil {
+			break
+		}
+		budget := max((dur-time.Since(start))/time.Duration(len(sizes)-i), 20*time.Millisecond)
+		ns := chaseLatency(ctx, ws, budget)
+		if ctx.Err() != nil {
+			break
+		}
+		if err := verifyChain(ws); err != nil {
+			return Result{Name: "Memory latency", Threads: 1, Err: err.Error(), Series: series}
+		}
+		series.Points = append(series.Points, Point{X: float64(size), Y: ns})
+		metrics["latency_ns"] = ns
+	}
+	if len(series.Points) < len(sizes) {
+		return Result{Name: "Memory latency", Threads: 1, Err: "canceled", Series: series}
+	}
+
+	last := sizes[len(sizes)-1]
+	return Result{
+		Name: "Memory latency", Threads: 1, Duration: time.Since(start), Throughput: Throughput{Value: metrics["latency_ns"], Unit: Nanoseconds},
+		Series: series, Metrics: metrics,
+		Notes: fmt.Sprintf("single-threaded pointer chase, DRAM @ %s, chains cycle-verified", humanBytes(last)),
+	}
+}
+
+// chainWalk is the most links verifyChain follows; past it a full walk
+// would take seconds, so large chains are walked only this far.
+const chainWalk = 1 << 17
+
+// verifyChain walks the chain in ws from node 0. A chain of up to
+// chainWalk nodes must close into one cycle through every node; a longer
+// one must stay in range and not close within chainWalk links.
+func verifyChain(ws []uint64) error {
+	const stride = cacheLine / 8
+	n := len(ws) / stride
+	what := humanBytes(uint64(len(ws)) * 8)
+	steps := min(n, chainWalk)
+	var p uint64
+	for i := 1; i <= steps; i++ {
+		p = ws[p*stride]
+		if p >= uint64(n) {
+			return verifyErr(what+" chain node", p, fmt.Sprintf("< %d", n))
+		}
+		if p == 0 && i != n {
+			return verifyErr(what+" chain cycle length", i, n)
+		}
+	}
+	if n <= chainWalk && p != 0 {
+		return verifyErr(what+" chain end", p, 0)
+	}
+	return nil
+}
+
+// linkChain links one node per cache line of ws into a single random
+// cycle (Sattolo's algorithm). A GiB-sized shuffle takes seconds, so it
+// gives up once ctx is done.
+func linkChain(ctx context.Context, ws []uint64, rng *rand.Rand) error {
+	const stride = cacheLine / 8
+	n := len(ws) / stride
+	for i := 0; i < n; i++ {
+		ws[i*stride] = uint64(i)
+	}
+	for i := n - 1; i > 0; i-- {
+		if i%(1<<16) == 0 && ctx.Err() != nil {
+			return ctx.Err()
+		}
+		j := rng.Intn(i)
+		ws[i*stride], ws[j*stride] = ws[j*stride], ws[i*stride]
+	}
+	return nil
+}
+
+// chaseLatency follows the chain in ws for budget, or until ctx is done,
+// and returns the mean ns per dependent load.
+func chaseLatency(ctx context.Context, ws []uint64, budget time.Duration) float64 {
+	const stride = cacheLine / 8
+	const chunk = 1 << 14
+	var p uint64
+	var steps uint64
+	start := time.Now()
+	for time.Since(start) < budget && ctx.Err() == nil {
+		for k := 0; k < chunk; k += 4 {
+			p = ws[p*stride]
+			p = ws[p*stride]
+			p = ws[p*stride]
+			p = ws[p*stride]
+		}
+		steps += chunk
+	}
+	elapsed := time.Since(start)
+	memLatSink = p
+	return float64(elapsed.Nanoseconds()) / float64(max(steps, 1))
+}
@@ -0,0 +1,237 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// NUMANode is a memory node and the CPUs local to it.
+type NUMANode struct {
+	ID   int
+	CPUs []int
+}
+
+// numaLatencyBytes is the pointer-chase working set, well past any LLC.
+const numaLatencyBytes = 256 << 20
+
+// RunMemNUMA measures copy bandwidth and load latency from the CPUs of
+// every NUMA node to memory placed on every node, as two CPU node ×
+// memory node matrices. Workers are pinned to the CPU node; memory is
+// placed by first touch from the memory node. The headline value is the
+// mean local bandwidth. On a single node only the local figures exist;
+// without node information the test is skipped.
+func RunMemNUMA(ctx context.Context, dur time.Duration, threads int, nodes []NUMANode, maxBytes uint64) Result {
+	const name = "NUMA memory"
+	if len(nodes) == 0 {
+		return Skip(name, threads, "NUMA topology unavailable")
+	}
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	n := len(nodes)
+	per := max(dur/time.Duration(2*n*n), 100*time.Millisecond)
+	latBytes := int(min(maxBytes, numaLatencyBytes))
+	bwBytes := int(min(maxBytes/2, 512<<20)) // each of src and dst
+
+	bw := newNodeMatrix("Copy bandwidth", "MiB/s", nodes)
+	lat := newNodeMatrix("Load latency", "ns", nodes)
+	fail := func(err string) Result {
+		return Result{Name: name, Threads: threads, Err: err, Matrices: []*Matrix{bw, lat}}
+	}
+	rng := rand.New(rand.NewSource(7))
+	var total uint64
+	workers := 0
+	start := time.Now()
+
+	for m, mem := range nodes {
+		src, freeSrc, err := allocOnNode(mem.CPUs[0], bwBytes)
+		if err != nil {
+			return fail(fmt.Sprintf("node %d: %v", mem.ID, err))
+		}
+		dst, freeDst, err := allocOnNode(mem.CPUs[0], bwBytes)
+		if err != nil {
+			freeSrc()
+			return fail(fmt.Sprintf("node %d: %v", mem.ID, err))
+		}
+		ws, freeWS, err := allocOnNode(mem.CPUs[0], latBytes)
+		if err != nil {
+			freeSrc()
+			freeDst()
+			return fail(fmt.Sprintf("node %d: %v", mem.ID, err))
+		}
+		for i := range src {
+			src[i] = byte(i*7 + i>>12)
+		}
+		// the chain is linked once per memory node and only read by the
+		// chases from every CPU node
+		words := unsafe.Slice((*uint64)(unsafe.Pointer(&ws[0])), len(ws)/8)
+		if linkChain(ctx, words, rng) != nil {
+			err = context.Canceled
+		} else if err = verifyChain(words); err != nil {
+			err = fmt.Errorf("memory node %d: %w", mem.ID, err)
+		}
+
+		for c, cpu := range nodes {
+			if err != nil {
+				break
+			}
+			if ctx.Err() != nil {
+				err = context.Canceled
+				break
+			}
+			w := min(threads, len(cpu.CPUs))
+			workers = max(workers, w)
+			var copied uint64
+			var elapsed time.Duration
+			copied, elapsed, err = numaCopy(ctx, per, cpu.CPUs[:w], src, dst)
+			if err != nil {
+				err = fmt.Errorf("CPU node %d → memory node %d: %w", cpu.ID, mem.ID, err)
+				break
+			}
+			total += copied
+			bw.Values[c][m] = float64(copied) / elapsed.Seconds() / (1 << 20)
+			var ns float64
+			ns, err = numaChase(ctx, cpu.CPUs[0], words, per)
+			if err != nil {
+				err = fmt.Errorf("CPU node %d → memory node %d: %w", cpu.ID, mem.ID, err)
+				break
+			}
+			lat.Values[c][m] = ns
+		}
+		freeSrc()
+		freeDst()
+		freeWS()
+		if err == context.Canceled {
+			return fail("canceled")
+		}
+		if err != nil {
+			return fail(err.Error())
+		}
+	}
+	elapsed := time.Since(start)
+
+	metrics := map[string]float64{}
+	var localBW, remoteBW, localNS, remoteNS float64
+	for c := range nodes {
+		for m := range nodes {
+			metrics[fmt.Sprintf("bw_c%d_m%d_mib_per_s", nodes[c].ID, nodes[m].ID)] = bw.Values[c][m]
+			metrics[fmt.Sprintf("lat_c%d_m%d_ns", nodes[c].ID, nodes[m].ID)] = lat.Values[c][m]
+			if c == m {
+				localBW += bw.Values[c][m] / float64(n)
+				localNS += lat.Values[c][m] / float64(n)
+			} else {
+				remoteBW += bw.Values[c][m] / float64(n*(n-1))
+				remoteNS += lat.Values[c][m] / float64(n*(n-1))
+			}
+		}
+	}
+	metrics["local_mib_per_s"], metrics["local_ns"] = localBW, localNS
+	notes := fmt.Sprintf("single NUMA node: local only, %.0f MiB/s, %.1f ns", localBW, localNS)
+	if n > 1 {
+		metrics["remote_mib_per_s"], metrics["remote_ns"] = remoteBW, remoteNS
+		notes = fmt.Sprintf("%d nodes: local %.0f MiB/s %.1f ns, remote %.0f MiB/s %.1f ns (%.0f%% of local bandwidth)",
+			n, localBW, localNS, remoteBW, remoteNS, 100*remoteBW/localBW)
+	}
+	return Result{
+		Name: name, Threads: workers, Duration: elapsed, Bytes: total,
+		Throughput: Throughput{Value: localBW * (1 << 20), Unit: BytesPerSec},
+		Matrices:   []*Matrix{bw, lat}, Metrics: metrics,
+		Notes: notes + "; copies and chase cycles verified",
+	}
+}
+
+func newNodeMatrix(title, unit string, nodes []NUMANode) *Matrix {
+	mx := &Matrix{Title: title, RowLabel: "CPU node", ColLabel: "memory node", Unit: unit}
+	for _, nd := range nodes {
+		label := fmt.Sprintf("node %d", nd.ID)
+		mx.Rows = append(mx.Rows, label)
+		mx.Cols = append(mx.Cols, label)
+		mx.Values = append(mx.Values, make([]float64, len(nodes)))
+	}
+	return mx
+}
+
+// numaCopy copies src to dst for dur with one worker per CPU in cpus,
+// each pinned to its CPU and copying its own slice, then checks every
+// slice arrived intact.
+func numaCopy(ctx context.Context, dur time.Duration, cpus []int, src, dst []byte) (uint64, time.Duration, error) {
+	pn := &pinner{cpus: cpus, used: map[int]bool{}}
+	ctx, cancel := context.WithTimeout(context.WithValue(ctx, pinKey{}, pn), dur)
+	defer cancel()
+	w := len(cpus)
+	chunk := len(src) / w &^ 4095
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var total uint64
+	var fail firstError
+	start := time.Now()
+	for i := 0; i < w; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer pinWorker(ctx, i)()
+			s, d := src[i*chunk:(i+1)*chunk], dst[i*chunk:(i+1)*chunk]
+			clear(d)
+			var local uint64
+			for ctx.Err() == nil {
+				local += uint64(copy(d, s))
+			}
+			if !bytes.Equal(d, s) {
+				fail.set(verifyErr(fmt.Sprintf("worker %d copy", i), "mismatch", "equal"))
+			}
+			mu.Lock()
+			total += local
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+	if err := pn.fail.get(); err != nil {
+		return 0, 0, err
+	}
+	return total, elapsed, fail.get()
+}
+
+// numaChase runs chaseLatency over the chain in ws pinned to cpu.
+func numaChase(ctx context.Context, cpu int, ws []uint64, budget time.Duration) (float64, error) {
+	pn := &pinner{cpus: []int{cpu}, used: map[int]bool{}}
+	ctx = context.WithValue(ctx, pinKey{}, pn)
+	var ns float64
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer pinWorker(ctx, 0)()
+		ns = chaseLatency(ctx, ws, budget)
+	}()
+	<-done
+	if err := pn.fail.get(); err != nil {
+		return 0, err
+	}
+	return ns, nil
+}
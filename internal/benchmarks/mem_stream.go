@@ -0,0 +1,194 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+type StreamKernel int
+
+const (
+	StreamCopy  StreamKernel = iota // c = a
+	StreamScale                     // b = q*c
+	StreamAdd                       // c = a + b
+	StreamTriad                     // a = b + q*c
+)
+
+func (k StreamKernel) String() string {
+	switch k {
+	case StreamCopy:
+		return "Copy"
+	case StreamScale:
+		return "Scale"
+	case StreamAdd:
+		return "Add"
+	case StreamTriad:
+		return "Triad"
+	}
+	return "?"
+}
+
+// bytesPerElem follows STREAM's accounting: bytes read plus bytes written.
+func (k StreamKernel) bytesPerElem() uint64 {
+	if k == StreamAdd || k == StreamTriad {
+		return 24
+	}
+	return 16
+}
+
+// StreamArrayLen sizes each STREAM array (in float64 elements) to at least
+// four times the last-level cache, as STREAM requires, and never below
+// 64 MiB; all three arrays together stay within an eighth of RAM.
+func StreamArrayLen(llcBytes, totalRAM uint64) int {
+	const floor = 64 << 20
+	size := 4 * llcBytes
+	if size < floor {
+		size = floor
+	}
+	if totalRAM > 0 {
+		if limit := totalRAM / 8 / 3; size > limit && limit >= 8<<20 {
+			size = limit
+		}
+	}
+	return int(size / 8)
+}
+
+// RunStream runs one STREAM kernel over float64 arrays of n elements. In
+// multi-threaded mode the arrays are split into contiguous per-worker
+// chunks, each allocated and first touched by its own worker.
+func RunStream(ctx context.Context, dur time.Duration, threads int, kernel StreamKernel, n int) Result {
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	if n <= 0 {
+		n = StreamArrayLen(0, 0)
+	}
+	name := "STREAM " + kernel.String()
+
+	chunk := (n + threads - 1) / threads
+	workers := (n + chunk - 1) / chunk
+	const q = 3.0
+	// each worker allocates and first-touches its own chunk, and keeps it
+	// here so the target array can be checked once the clock stops
+	type streamChunk struct {
+		a, b, c []float64
+		passes  int
+	}
+	var mu sync.Mutex
+	var chunks []*streamChunk
+	bytes, elapsed, _, err := runWorkers(ctx, dur, workers, func() workFn {
+		mu.Lock()
+		lo := len(chunks) * chunk
+		m := min(lo+chunk, n) - lo
+		sc := &streamChunk{a: make([]float64, m), b: make([]float64, m), c: make([]float64, m)}
+		chunks = append(chunks, sc)
+		mu.Unlock()
+		for i := range sc.a {
+			sc.a[i], sc.b[i], sc.c[i] = 1, 2, 0.5
+		}
+		per := uint64(m) * kernel.bytesPerElem()
+		return func() (uint64, error) {
+			streamPass(kernel, sc.a, sc.b, sc.c, q)
+			sc.passes++
+			return per, nil
+		}
+	})
+	for _, sc := range chunks {
+		if err == nil && sc.passes > 0 {
+			err = streamVerify(kernel, sc.a, sc.b, sc.c, q)
+		}
+	}
+	if err != nil {
+		return Result{Name: name, Threads: threads, Err: err.Error()}
+	}
+	return Result{Name: name, Threads: threads, Duration: elapsed, Bytes: bytes, Throughput: perSecond(bytes, elapsed, BytesPerSec),
+		Checksum: fmt.Sprintf("%s=%g", kernel.target(), kernel.expect(q)),
+		Notes:    fmt.Sprintf("3 x %s arrays", humanBytes(uint64(n)*8))}
+}
+
+// target names the array each kernel writes.
+func (k StreamKernel) target() string {
+	switch k {
+	case StreamScale:
+		return "b"
+	case StreamTriad:
+		return "a"
+	}
+	return "c"
+}
+
+// expect is the value every element of the target array holds after any
+// number of passes, given the initial a=1, b=2, c=0.5.
+func (k StreamKernel) expect(q float64) float64 {
+	switch k {
+	case StreamCopy:
+		return 1
+	case StreamScale:
+		return q * 0.5
+	case StreamAdd:
+		return 1 + 2
+	}
+	return 2 + q*0.5
+}
+
+func streamVerify(k StreamKernel, a, b, c []float64, q float64) error {
+	dst := c
+	switch k {
+	case StreamScale:
+		dst = b
+	case StreamTriad:
+		dst = a
+	}
+	want := k.expect(q)
+	for i, v := range dst {
+		if v != want {
+			return verifyErr(fmt.Sprintf("%s[%d]", k.target(), i), v, want)
+		}
+	}
+	return nil
+}
+
+func streamPass(k StreamKernel, a, b, c []float64, q float64) {
+	switch k {
+	case StreamCopy:
+		copy(c, a)
+	case StreamScale:
+		b = b[:len(c)]
+		for i := range c {
+			b[i] = q * c[i]
+		}
+	case StreamAdd:
+		a, b = a[:len(c)], b[:len(c)]
+		for i := range c {
+			c[i] = a[i] + b[i]
+		}
+	case StreamTriad:
+		b, c = b[:len(a)], c[:len(a)]
+		for i := range a {
+			a[i] = b[i] + q*c[i]
+		}
+	}
+}
@@ -0,0 +1,66 @@
+//go:build linux
+
+// internal/benchmarks/numa_linux.go
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"os"
+	"runtime"
+
+	"golang.org/x/sys/unix"
+)
+
+// allocOnNode maps size bytes and faults every page in from cpu, so that
+// under the kernel's default first-touch policy the memory lands on cpu's
+// NUMA node. A fresh mapping is used because Go heap memory may already
+// be backed by pages on another node. free unmaps it.
+func allocOnNode(cpu, size int) (buf []byte, free func(), err error) {
+	// on a goroutine of its own, so that if the old mask can't be
+	// restored the pinned thread exits with it
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		runtime.LockOSThread()
+		restore, perr := setAffinity(cpu)
+		if perr != nil {
+			err = perr
+			runtime.UnlockOSThread()
+			return
+		}
+		buf, err = unix.Mmap(-1, 0, size, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_PRIVATE|unix.MAP_ANONYMOUS)
+		if err == nil {
+			page := os.Getpagesize()
+			for i := 0; i < len(buf); i += page {
+				buf[i] = 1
+			}
+		}
+		if restore() == nil {
+			runtime.UnlockOSThread()
+		}
+	}()
+	<-done
+	if err != nil {
+		return nil, nil, err
+	}
+	return buf, func() { _ = unix.Munmap(buf) }, nil
+}
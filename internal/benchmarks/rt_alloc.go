@@ -0,0 +1,181 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// allocNode is a 64-byte, pointer-carrying object: the common size class
+// of small structs in Go services.
+type allocNode struct {
+	next *allocNode
+	v    [7]uint64
+}
+
+// allocBatch is how many nodes a worker links up before dropping them.
+const allocBatch = 256
+
+// RunAllocRate measures small-object allocation throughput. Each batch
+// is linked into a list, summed, and dropped for the collector.
+func RunAllocRate(ctx context.Context, dur time.Duration, threads int) Result {
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	const want = allocBatch * (allocBatch - 1) / 2
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var ops uint64
+	var fail firstError
+
+	gw := startGCWindow()
+	ctx, cancel := context.WithTimeout(ctx, dur)
+	defer cancel()
+	start := time.Now()
+	for i := 0; i < threads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer pinWorker(ctx, i)()
+			var local uint64
+			for {
+				select {
+				case <-ctx.Done():
+					mu.Lock()
+					ops += local
+					mu.Unlock()
+					return
+				default:
+					var head *allocNode
+					for j := uint64(0); j < allocBatch; j++ {
+						head = &allocNode{next: head, v: [7]uint64{j}}
+					}
+					var sum uint64
+					for n := head; n != nil; n = n.next {
+						sum += n.v[0]
+					}
+					if sum != want {
+						fail.set(verifyErr("alloc list sum", sum, want))
+					}
+					local += allocBatch
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+	pauses, m := gw.finish()
+	if err := fail.get(); err != nil {
+		return Result{Name: "Alloc rate", Threads: threads, Err: err.Error()}
+	}
+	return Result{Name: "Alloc rate", Threads: threads, Duration: elapsed, Ops: ops, Throughput: perSecond(ops, elapsed, AllocsPerSec),
+		Latency: pauses, Metrics: m, Notes: "64 B objects; " + gcNotes(m)}
+}
+
+// gcNode is one 128-byte object of the live heap.
+type gcNode struct {
+	next *gcNode
+	slot uint64
+	tag  uint64
+	pad  [13]uint64
+}
+
+func gcTag(slot uint64) uint64 { return slot*0x9e3779b97f4a7c15 ^ 0x5bd1e995 }
+
+// DefaultLiveHeapMB is the live heap RunGCPressure keeps by default.
+const DefaultLiveHeapMB = 256
+
+// RunGCPressure keeps liveMB of pointer-linked objects reachable while
+// the workers keep replacing random ones, so every cycle has to mark a
+// heap of that size. Throughput is replacements per second; the latency
+// columns show the GC pauses.
+func RunGCPressure(ctx context.Context, dur time.Duration, threads, liveMB int) Result {
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	if liveMB <= 0 {
+		liveMB = DefaultLiveHeapMB
+	}
+	n := liveMB << 20 / 128
+	// nodes link only into a fixed set that is never replaced, so a
+	// replaced node is garbage at once and the live heap stays at liveMB
+	var anchors [64]gcNode
+	live := make([]*gcNode, n)
+	for i := range live {
+		live[i] = &gcNode{next: &anchors[i%len(anchors)], slot: uint64(i), tag: gcTag(uint64(i))}
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var ops uint64
+	stripe := (n + threads - 1) / threads
+
+	gw := startGCWindow()
+	ctx, cancel := context.WithTimeout(ctx, dur)
+	defer cancel()
+	start := time.Now()
+	for t := 0; t < threads; t++ {
+		lo, hi := min(t*stripe, n), min((t+1)*stripe, n)
+		if lo == hi {
+			continue
+		}
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			defer pinWorker(ctx, t)()
+			r := rand.New(rand.NewSource(seed))
+			var local uint64
+			for {
+				select {
+				case <-ctx.Done():
+					mu.Lock()
+					ops += local
+					mu.Unlock()
+					return
+				default:
+					for k := 0; k < 64; k++ {
+						i := lo + r.Intn(hi-lo)
+						live[i] = &gcNode{next: &anchors[i%len(anchors)], slot: uint64(i), tag: gcTag(uint64(i))}
+					}
+					local += 64
+				}
+			}
+		}(int64(t + 1))
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+	pauses, m := gw.finish()
+
+	// the live set must come through all those cycles intact
+	for i, node := range live {
+		if node.slot != uint64(i) || node.tag != gcTag(uint64(i)) {
+			return Result{Name: "GC pressure", Threads: threads, Err: verifyErr(fmt.Sprintf("live node %d", i), node.tag, gcTag(uint64(i))).Error()}
+		}
+	}
+	runtime.KeepAlive(live)
+	return Result{Name: "GC pressure", Threads: threads, Duration: elapsed, Ops: ops, Throughput: perSecond(ops, elapsed, AllocsPerSec),
+		Latency: pauses, Metrics: m, Notes: fmt.Sprintf("live heap %d MB; %s", liveMB, gcNotes(m))}
+}
@@ -0,0 +1,163 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// RunChanPingPong bounces a counter between goroutine pairs over
+// unbuffered channels; every round trip is two handoffs through the
+// scheduler. threads goroutines make threads/2 pairs (at least one).
+func RunChanPingPong(ctx context.Context, dur time.Duration, threads int) Result {
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	pairs := max(1, threads/2)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var ops uint64
+	var fail firstError
+	lat := NewHistogram()
+
+	gw := startGCWindow()
+	ctx, cancel := context.WithTimeout(ctx, dur)
+	defer cancel()
+	start := time.Now()
+	for p := 0; p < pairs; p++ {
+		ping, pong := make(chan uint64), make(chan uint64)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			defer pinWorker(ctx, 2*p)()
+			for v := range ping {
+				pong <- v + 1
+			}
+			close(pong)
+		}()
+		go func() {
+			defer wg.Done()
+			defer pinWorker(ctx, 2*p+1)()
+			defer close(ping)
+			h := NewHistogram()
+			var v uint64
+			for {
+				select {
+				case <-ctx.Done():
+					mu.Lock()
+					ops += v
+					lat.Merge(h)
+					mu.Unlock()
+					return
+				default:
+					t0 := time.Now()
+					ping <- v
+					got := <-pong
+					h.Record(time.Since(t0))
+					if got != v+1 {
+						fail.set(verifyErr("pong value", got, v+1))
+					}
+					v = got
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+	_, m := gw.finish()
+	if err := fail.get(); err != nil {
+		return Result{Name: "Chan ping-pong", Threads: threads, Err: err.Error()}
+	}
+	return Result{Name: "Chan ping-pong", Threads: pairs * 2, Duration: elapsed, Ops: ops, Throughput: perSecond(ops, elapsed, RoundTripsPerSec),
+		Latency: lat, Metrics: m, Notes: fmt.Sprintf("%d pairs, latency = round trip; %s", pairs, gcNotes(m))}
+}
+
+// lockSampleEvery is how often (in acquisitions) a mutex worker times
+// its Lock; two clock reads cost as much as an uncontended lock.
+const lockSampleEvery = 32
+
+// RunMutexContention has every worker hammer one sync.Mutex guarding a
+// counter and a cache line of shared state. The latency columns show
+// the time spent waiting in Lock, sampled every lockSampleEvery
+// acquisitions.
+func RunMutexContention(ctx context.Context, dur time.Duration, threads int) Result {
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	var shared struct {
+		sync.Mutex
+		count uint64
+		line  [8]uint64
+	}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var ops uint64
+	lat := NewHistogram()
+
+	gw := startGCWindow()
+	ctx, cancel := context.WithTimeout(ctx, dur)
+	defer cancel()
+	start := time.Now()
+	for i := 0; i < threads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer pinWorker(ctx, i)()
+			h := NewHistogram()
+			var local uint64
+			for {
+				select {
+				case <-ctx.Done():
+					mu.Lock()
+					ops += local
+					lat.Merge(h)
+					mu.Unlock()
+					return
+				default:
+					if local%lockSampleEvery == 0 {
+						t0 := time.Now()
+						shared.Lock()
+						h.Record(time.Since(t0))
+					} else {
+						shared.Lock()
+					}
+					shared.count++
+					shared.line[shared.count%8] += shared.count
+					shared.Unlock()
+					local++
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+	_, m := gw.finish()
+	// a lost update means the lock did not exclude
+	if shared.count != ops {
+		return Result{Name: "Mutex contention", Threads: threads, Err: verifyErr("locked counter", shared.count, ops).Error()}
+	}
+	return Result{Name: "Mutex contention", Threads: threads, Duration: elapsed, Ops: ops, Throughput: perSecond(ops, elapsed, LocksPerSec),
+		Latency: lat, Metrics: m, Notes: fmt.Sprintf("lock wait %.1f ms total; %s", m["mutex_wait_ms"], gcNotes(m))}
+}
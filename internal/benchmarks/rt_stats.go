@@ -0,0 +1,102 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/metrics"
+	"time"
+)
+
+// gcWindow brackets a runtime test with two MemStats/runtime/metrics
+// snapshots so it can report what the collector did in between.
+type gcWindow struct {
+	ms      runtime.MemStats
+	samples []metrics.Sample
+}
+
+var gcMetricNames = []string{
+	"/gc/heap/allocs:bytes",
+	"/gc/heap/allocs:objects",
+	"/sync/mutex/wait/total:seconds",
+}
+
+// startGCWindow collects leftovers from earlier tests first so they do
+// not show up as this test's pauses.
+func startGCWindow() *gcWindow {
+	runtime.GC()
+	w := &gcWindow{samples: make([]metrics.Sample, len(gcMetricNames))}
+	for i, n := range gcMetricNames {
+		w.samples[i].Name = n
+	}
+	metrics.Read(w.samples)
+	runtime.ReadMemStats(&w.ms)
+	return w
+}
+
+func sampleValue(s metrics.Sample) float64 {
+	switch s.Value.Kind() {
+	case metrics.KindUint64:
+		return float64(s.Value.Uint64())
+	case metrics.KindFloat64:
+		return s.Value.Float64()
+	}
+	return 0
+}
+
+// finish returns the pauses of the collections since start (at most the
+// 256 that MemStats keeps) and the GC counters as Result metrics.
+func (w *gcWindow) finish() (*Histogram, map[string]float64) {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	now := make([]metrics.Sample, len(w.samples))
+	copy(now, w.samples)
+	metrics.Read(now)
+
+	pauses := NewHistogram()
+	cycles := ms.NumGC - w.ms.NumGC
+	for i := uint32(0); i < min(cycles, uint32(len(ms.PauseNs))); i++ {
+		pauses.Record(time.Duration(ms.PauseNs[(ms.NumGC-i+255)%256]))
+	}
+	m := map[string]float64{
+		"gc_cycles":         float64(cycles),
+		"gc_pause_total_ms": float64(ms.PauseTotalNs-w.ms.PauseTotalNs) / 1e6,
+		"heap_alloc_mb":     float64(ms.HeapAlloc) / (1 << 20),
+		"alloc_mb":          (sampleValue(now[0]) - sampleValue(w.samples[0])) / (1 << 20),
+		"alloc_objects":     sampleValue(now[1]) - sampleValue(w.samples[1]),
+		"mutex_wait_ms":     (sampleValue(now[2]) - sampleValue(w.samples[2])) * 1e3,
+	}
+	if pauses.Count() > 0 {
+		m["gc_pause_p50_us"] = float64(pauses.Percentile(50)) / 1e3
+		m["gc_pause_p99_us"] = float64(pauses.Percentile(99)) / 1e3
+		m["gc_pause_max_us"] = float64(pauses.Max()) / 1e3
+	}
+	return pauses, m
+}
+
+// gcNotes summarises the window metrics for the table.
+func gcNotes(m map[string]float64) string {
+	if m["gc_cycles"] == 0 {
+		return "no GC cycles"
+	}
+	return fmt.Sprintf("%.0f GCs, pause p50 %.0f µs, p99 %.0f µs", m["gc_cycles"], m["gc_pause_p50_us"], m["gc_pause_p99_us"])
+}
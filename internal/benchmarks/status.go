@@ -0,0 +1,87 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Status is how a test run ended. Only passed results are scored; the
+// zero value is pending, so a result nobody settled never counts as
+// passed.
+type Status int
+
+const (
+	StatusPending Status = iota // not settled yet
+	StatusPassed
+	StatusFailed   // ran, but returned an error or failed verification
+	StatusSkipped  // not run, e.g. unsupported here or suite canceled
+	StatusCanceled // stopped by the user while running
+)
+
+var statusNames = [...]string{"pending", "passed", "failed", "skipped", "canceled"}
+
+func (s Status) String() string {
+	if s < 0 || int(s) >= len(statusNames) {
+		return fmt.Sprintf("Status(%d)", int(s))
+	}
+	return statusNames[s]
+}
+
+func (s Status) MarshalJSON() ([]byte, error) { return json.Marshal(s.String()) }
+
+func (s *Status) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err != nil {
+		return err
+	}
+	for i, n := range statusNames {
+		if n == name {
+			*s = Status(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown status %q", name)
+}
+
+// Skip returns the result of a test that was not run, with the reason.
+func Skip(name string, threads int, reason string) Result {
+	return Result{Name: name, Threads: threads, Status: StatusSkipped, Err: reason}
+}
+
+// Settle sets r.Status from how the run ended; canceled reports whether
+// the user stopped it. Benchmarks only fill in Err, so the runner calls
+// this on every result it collects.
+func (r *Result) Settle(canceled bool) {
+	switch {
+	case r.Status == StatusSkipped:
+	case canceled || r.Err == "canceled":
+		r.Status = StatusCanceled
+		if r.Err == "" {
+			r.Err = "canceled"
+		}
+	case r.Err != "":
+		r.Status = StatusFailed
+	default:
+		r.Status = StatusPassed
+	}
+}
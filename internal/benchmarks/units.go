@@ -0,0 +1,116 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package benchmarks
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Unit is what a Throughput counts per second. It is serialized by its
+// symbol so exported files stay readable.
+type Unit int
+
+const (
+	UnitNone Unit = iota
+	BytesPerSec
+	OpsPerSec
+	HashesPerSec
+	AllocsPerSec
+	RoundTripsPerSec
+	LocksPerSec
+	ItemsPerSec
+	EdgesPerSec
+	PointsPerSec
+	InteractionsPerSec
+	PixelsPerSec
+	FLOPS
+	Nanoseconds // a latency rather than a rate: lower is better
+)
+
+var unitSymbols = [...]string{"", "B/s", "ops/s", "hash/s", "allocs/s", "rt/s", "locks/s", "items/s",
+	"edges/s", "points/s", "interactions/s", "px/s", "FLOP/s", "ns"}
+
+func (u Unit) String() string {
+	if u < 0 || int(u) >= len(unitSymbols) {
+		return fmt.Sprintf("Unit(%d)", int(u))
+	}
+	return unitSymbols[u]
+}
+
+// LowerIsBetter reports whether smaller values of u are faster.
+func (u Unit) LowerIsBetter() bool { return u == Nanoseconds }
+
+func (u Unit) MarshalJSON() ([]byte, error) { return json.Marshal(u.String()) }
+
+func (u *Unit) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	for i, sym := range unitSymbols {
+		if sym == s {
+			*u = Unit(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown unit %q", s)
+}
+
+// Throughput is a measured rate in base units per second (bytes, not
+// MiB; FLOP, not GFLOP), or a latency in ns when Unit is Nanoseconds.
+type Throughput struct {
+	Value float64 `json:"value"`
+	Unit  Unit    `json:"unit"`
+}
+
+// perSecond is the one place a count and the measured run time become a
+// rate; durations are never rounded.
+func perSecond(n uint64, d time.Duration, u Unit) Throughput {
+	if d <= 0 {
+		return Throughput{Unit: u}
+	}
+	return Throughput{Value: float64(n) / d.Seconds(), Unit: u}
+}
+
+// String scales the value for display: IEC prefixes for bytes
+// (1.50 GiB/s), SI prefixes for everything else (12.3 Mpx/s).
+func (t Throughput) String() string {
+	switch {
+	case t.Value <= 0:
+		return "—"
+	case t.Unit == Nanoseconds:
+		return fmt.Sprintf("%.1f ns", t.Value)
+	case t.Unit == BytesPerSec:
+		return scaled(t.Value, 1024, []string{"", "Ki", "Mi", "Gi", "Ti"}) + "B/s"
+	}
+	return scaled(t.Value, 1000, []string{"", "k", "M", "G", "T"}) + t.Unit.String()
+}
+
+func scaled(v, base float64, prefixes []string) string {
+	i := 0
+	for v >= base && i < len(prefixes)-1 {
+		v /= base
+		i++
+	}
+	return fmt.Sprintf("%.2f %s", v, prefixes[i])
+}
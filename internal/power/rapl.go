@@ -0,0 +1,187 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package power reads package energy from the Linux powercap interface
+// (Intel and AMD RAPL) so tests can report power and work per joule.
+package power
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultRoot is where sysfs is mounted. Open takes the root as an
+// argument so the parsers can be pointed at a fake tree.
+const DefaultRoot = "/sys"
+
+// ErrUnavailable is returned by Open when there are no readable RAPL
+// package zones, e.g. on other OSes, in VMs or without read permission
+// on energy_uj (root-only on most kernels since 5.10).
+var ErrUnavailable = errors.New("RAPL energy counters unavailable")
+
+// Zone is one top-level RAPL zone, normally a CPU package.
+type Zone struct {
+	Name     string // e.g. "package-0"
+	Dir      string
+	MaxRange uint64 // highest energy_uj value; the next µJ wraps it to 0
+}
+
+// Meter samples the energy counters of all package zones.
+type Meter struct {
+	Zones []Zone
+}
+
+// Sample is one reading of every zone's counter, in µJ.
+type Sample struct {
+	At     time.Time
+	Energy []uint64
+}
+
+// Reading is the energy used between two samples.
+type Reading struct {
+	Joules  float64
+	Elapsed time.Duration
+}
+
+// Watts is the average power over the reading.
+func (r Reading) Watts() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return r.Joules / r.Elapsed.Seconds()
+}
+
+// Open finds the package zones under root/class/powercap. Subzones (core,
+// uncore, dram) are counted in their package and psys overlaps the
+// packages, so both are skipped.
+func Open(root string) (*Meter, error) {
+	base := filepath.Join(root, "class", "powercap")
+	ents, err := os.ReadDir(base)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	m := &Meter{}
+	var lastErr error
+	for _, e := range ents {
+		z, ok, err := parseZone(filepath.Join(base, e.Name()))
+		if err != nil {
+			lastErr = err
+		}
+		if ok {
+			m.Zones = append(m.Zones, z)
+		}
+	}
+	if len(m.Zones) == 0 {
+		if lastErr != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnavailable, lastErr)
+		}
+		return nil, fmt.Errorf("%w: no package zones in %s", ErrUnavailable, base)
+	}
+	sort.Slice(m.Zones, func(i, j int) bool { return m.Zones[i].Name < m.Zones[j].Name })
+	return m, nil
+}
+
+// parseZone reads a powercap zone directory and reports whether it is a
+// readable top-level package zone.
+func parseZone(dir string) (Zone, bool, error) {
+	// top-level zones are "intel-rapl:N"; subzones add ":M"
+	if base := filepath.Base(dir); !strings.HasPrefix(base, "intel-rapl:") || strings.Count(base, ":") != 1 {
+		return Zone{}, false, nil
+	}
+	name, err := readString(filepath.Join(dir, "name"))
+	if err != nil {
+		return Zone{}, false, err
+	}
+	if !strings.HasPrefix(name, "package") {
+		return Zone{}, false, nil
+	}
+	rng, err := readUint(filepath.Join(dir, "max_energy_range_uj"))
+	if err != nil {
+		return Zone{}, false, err
+	}
+	// energy_uj is often mode 0400; check it can actually be read
+	if _, err := readUint(filepath.Join(dir, "energy_uj")); err != nil {
+		return Zone{}, false, err
+	}
+	return Zone{Name: name, Dir: dir, MaxRange: rng}, true, nil
+}
+
+// Read samples every zone's counter.
+func (m *Meter) Read() (Sample, error) {
+	s := Sample{At: time.Now(), Energy: make([]uint64, len(m.Zones))}
+	for i, z := range m.Zones {
+		v, err := readUint(filepath.Join(z.Dir, "energy_uj"))
+		if err != nil {
+			return Sample{}, err
+		}
+		s.Energy[i] = v
+	}
+	return s, nil
+}
+
+// Between returns the energy used from a to b, summed over zones. A
+// counter that wrapped once is corrected using its zone's range: it
+// counts 0..MaxRange, so a wrap adds MaxRange+1.
+func (m *Meter) Between(a, b Sample) Reading {
+	var uj uint64
+	for i, z := range m.Zones {
+		if i >= len(a.Energy) || i >= len(b.Energy) {
+			break
+		}
+		if b.Energy[i] >= a.Energy[i] {
+			uj += b.Energy[i] - a.Energy[i]
+		} else {
+			uj += z.MaxRange + 1 - a.Energy[i] + b.Energy[i]
+		}
+	}
+	return Reading{Joules: float64(uj) / 1e6, Elapsed: b.At.Sub(a.At)}
+}
+
+// String lists the zones, for the system info pane.
+func (m *Meter) String() string {
+	names := make([]string, len(m.Zones))
+	for i, z := range m.Zones {
+		names[i] = z.Name
+	}
+	return "RAPL " + strings.Join(names, ", ")
+}
+
+func readString(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+func readUint(path string) (uint64, error) {
+	s, err := readString(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
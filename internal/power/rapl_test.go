@@ -0,0 +1,138 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package power
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// zone writes a fake powercap zone; energy "" makes energy_uj unreadable.
+func zone(t *testing.T, root, dir, name, energy, rng string) {
+	t.Helper()
+	d := filepath.Join(root, "class", "powercap", dir)
+	if err := os.MkdirAll(d, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	write := func(f, v string) {
+		if err := os.WriteFile(filepath.Join(d, f), []byte(v+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("name", name)
+	write("max_energy_range_uj", rng)
+	if energy == "" {
+		// a directory fails to read even as root, unlike a 0400 file
+		if err := os.Mkdir(filepath.Join(d, "energy_uj"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	write("energy_uj", energy)
+}
+
+func TestOpenZones(t *testing.T) {
+	root := t.TempDir()
+	zone(t, root, "intel-rapl:0", "package-0", "1000", "262143328850")
+	zone(t, root, "intel-rapl:0:0", "core", "500", "262143328850")
+	zone(t, root, "intel-rapl:0:1", "dram", "500", "262143328850")
+	zone(t, root, "intel-rapl:1", "package-1", "2000", "262143328850")
+	zone(t, root, "intel-rapl:2", "psys", "9000", "262143328850")
+	zone(t, root, "intel-rapl-mmio:0", "package-0", "7000", "262143328850")
+
+	m, err := Open(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, z := range m.Zones {
+		names = append(names, z.Name+"@"+filepath.Base(z.Dir))
+	}
+	want := []string{"package-0@intel-rapl:0", "package-1@intel-rapl:1"}
+	if len(names) != len(want) || names[0] != want[0] || names[1] != want[1] {
+		t.Fatalf("zones = %v, want %v", names, want)
+	}
+	if m.Zones[0].MaxRange != 262143328850 {
+		t.Errorf("MaxRange = %d", m.Zones[0].MaxRange)
+	}
+	s, err := m.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Energy[0] != 1000 || s.Energy[1] != 2000 {
+		t.Errorf("sample = %v, want [1000 2000]", s.Energy)
+	}
+}
+
+func TestOpenUnavailable(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		setup func(root string)
+	}{
+		{"no powercap", func(string) {}},
+		{"unreadable energy_uj", func(root string) {
+			zone(t, root, "intel-rapl:0", "package-0", "", "262143328850")
+		}},
+		{"only subzones, psys and mmio", func(root string) {
+			zone(t, root, "intel-rapl:0:0", "core", "1", "100")
+			zone(t, root, "intel-rapl:1", "psys", "1", "100")
+			zone(t, root, "intel-rapl-mmio:0", "package-0", "1", "100")
+		}},
+		{"bad range", func(root string) {
+			zone(t, root, "intel-rapl:0", "package-0", "1", "lots")
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			root := t.TempDir()
+			tc.setup(root)
+			if _, err := Open(root); !errors.Is(err, ErrUnavailable) {
+				t.Fatalf("Open = %v, want ErrUnavailable", err)
+			}
+		})
+	}
+}
+
+func TestBetween(t *testing.T) {
+	m := &Meter{Zones: []Zone{{Name: "package-0", MaxRange: 999}, {Name: "package-1", MaxRange: 999}}}
+	at := time.Unix(100, 0)
+	for _, tc := range []struct {
+		name string
+		a, b []uint64
+		want float64 // joules
+	}{
+		{"no wrap", []uint64{100, 0}, []uint64{600, 1_000_000 - 999_001}, 0.000500 + 0.000999},
+		{"wrap", []uint64{900, 0}, []uint64{99, 0}, 0.000199},
+		{"wrap to zero", []uint64{999, 5}, []uint64{0, 5}, 0.000001},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := m.Between(Sample{At: at, Energy: tc.a}, Sample{At: at.Add(2 * time.Second), Energy: tc.b})
+			if d := r.Joules - tc.want; d > 1e-12 || d < -1e-12 {
+				t.Errorf("Joules = %g, want %g", r.Joules, tc.want)
+			}
+			if r.Elapsed != 2*time.Second || r.Watts() != r.Joules/2 {
+				t.Errorf("Elapsed = %v, Watts = %g", r.Elapsed, r.Watts())
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package scoring
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/e1z0/Benchy/internal/benchmarks"
+)
+
+// DefaultRefSet names the reference set scores are computed against
+// unless the user picks another.
+const DefaultRefSet = "benchy-2025-ref"
+
+//go:embed refs/*.json
+var builtinFS embed.FS
+
+// RefSet is a versioned set of per-test reference throughputs. A result
+// equal to its reference scores Baseline; the set's name is recorded in
+// every export so that scores from different sets are not compared.
+type RefSet struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Baseline    float64 `json:"baseline"`
+	// Watts is the reference machine's average package power while
+	// running the suite; 0 disables efficiency scores.
+	Watts float64 `json:"watts,omitempty"`
+	// References holds the reference throughput per test name, in the
+	// base unit of that test's Throughput (B/s, ops/s, ns, ...).
+	References map[string]float64 `json:"references"`
+	// Source is where the set was loaded from; empty for built-in sets.
+	Source string `json:"source,omitempty"`
+}
+
+// RefInfo identifies a reference set in exports.
+type RefInfo struct {
+	Name     string  `json:"name"`
+	Baseline float64 `json:"baseline"`
+	Watts    float64 `json:"watts,omitempty"`
+	Checksum string  `json:"checksum"`
+	Source   string  `json:"source,omitempty"`
+}
+
+// Info returns the export record for set. The checksum covers the
+// reference values, so an edited file that kept its name is still told
+// apart.
+func (set *RefSet) Info() RefInfo {
+	names := make([]string, 0, len(set.References))
+	for n := range set.References {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	h := crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	fmt.Fprintf(h, "baseline=%g watts=%g\n", set.Baseline, set.Watts)
+	for _, n := range names {
+		fmt.Fprintf(h, "%s=%g\n", n, set.References[n])
+	}
+	return RefInfo{Name: set.Name, Baseline: set.Baseline, Watts: set.Watts, Checksum: fmt.Sprintf("%08x", h.Sum32()), Source: set.Source}
+}
+
+// Builtin returns the names of the embedded reference sets.
+func Builtin() []string {
+	ents, _ := builtinFS.ReadDir("refs")
+	var names []string
+	for _, e := range ents {
+		names = append(names, strings.TrimSuffix(e.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadBuiltin returns the embedded reference set called name.
+func LoadBuiltin(name string) (*RefSet, error) {
+	b, err := builtinFS.ReadFile(path.Join("refs", name+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("no built-in reference set %q", name)
+	}
+	return parseRefSet(b, "")
+}
+
+// Default returns the default built-in reference set; it is embedded in
+// the binary, so failing to load it is a build error.
+func Default() *RefSet {
+	set, err := LoadBuiltin(DefaultRefSet)
+	if err != nil {
+		panic(err)
+	}
+	return set
+}
+
+// LoadFile reads a user-supplied reference set. It accepts either a
+// reference set file or a results file exported by Benchy, in which case
+// the exporting machine becomes the reference: each of its passing tests
+// scores exactly Baseline.
+func LoadFile(fn string) (*RefSet, error) {
+	b, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+	var probe struct {
+		Results json.RawMessage `json:"results"`
+	}
+	if err := json.Unmarshal(b, &probe); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(fn), err)
+	}
+	if probe.Results != nil {
+		return fromExport(b, fn)
+	}
+	return parseRefSet(b, fn)
+}
+
+func parseRefSet(b []byte, src string) (*RefSet, error) {
+	var set RefSet
+	if err := json.Unmarshal(b, &set); err != nil {
+		return nil, err
+	}
+	if set.Name == "" || len(set.References) == 0 {
+		return nil, errors.New("reference set needs a name and references")
+	}
+	if set.Baseline <= 0 {
+		set.Baseline = Baseline
+	}
+	set.Source = src
+	return &set, nil
+}
+
+// fromExport builds a reference set from an exported results file. Only
+// name, status, error and throughput are read, so exports from older or
+// newer versions with other fields still load. Exports from before
+// results had a status count a result without an error as passed.
+func fromExport(b []byte, src string) (*RefSet, error) {
+	var exp struct {
+		System struct {
+			MachineModel string `json:"machine_model"`
+			CPUModel     string `json:"cpu_model"`
+		} `json:"system"`
+		Results []struct {
+			Name       string                `json:"name"`
+			Threads    int                   `json:"threads"`
+			Status     string                `json:"status"`
+			Err        string                `json:"err"`
+			Throughput benchmarks.Throughput `json:"throughput"`
+			Energy     *benchmarks.Energy    `json:"energy"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(b, &exp); err != nil {
+		return nil, err
+	}
+	set := &RefSet{
+		Name:       strings.TrimSuffix(filepath.Base(src), filepath.Ext(src)),
+		Baseline:   Baseline,
+		References: map[string]float64{},
+		Source:     src,
+	}
+	threads := 0
+	var watts float64
+	var metered int
+	for _, r := range exp.Results {
+		passed := r.Status == benchmarks.StatusPassed.String() || r.Status == ""
+		if !passed || r.Err != "" || r.Throughput.Value <= 0 {
+			continue
+		}
+		set.References[r.Name] = r.Throughput.Value
+		threads = max(threads, r.Threads)
+		if r.Energy != nil && r.Energy.Watts > 0 {
+			watts += r.Energy.Watts
+			metered++
+		}
+	}
+	if metered > 0 {
+		set.Watts = watts / float64(metered)
+	}
+	if len(set.References) == 0 {
+		return nil, errors.New("export has no passing results")
+	}
+	machine := strings.TrimSpace(exp.System.MachineModel + " " + exp.System.CPUModel)
+	if machine == "" {
+		machine = "unknown machine"
+	}
+	set.Description = fmt.Sprintf("exported results of %s, %d threads", machine, threads)
+	return set, nil
+}
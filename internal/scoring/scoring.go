@@ -20,60 +20,39 @@
  */
 package scoring
 
-import (
-	"math"
-
-	"github.com/e1z0/Benchy/internal/benchmarks"
-)
+import "github.com/e1z0/Benchy/internal/benchmarks"
 
+// Baseline is the score a result equal to its reference earns.
 const Baseline = 2500.0
 
-var Reference = map[string]float64{
-	"CPU SHA-256":         200000.0,    // hash/s
-	"AES-CTR":             1500 << 20,  // B/s
-	"Zstd Compress":       400 << 20,   // B/s
-	"Gzip Compress":       250 << 20,   // B/s
-	"JSON Parse":          300 << 20,   // B/s
-	"MatMul":              50.0,        // GFLOP/s
-	"Memory copy":         20000 << 20, // B/s
-	"Gaussian Blur 1080p": 30e6,        // px/s
-	"Disk seq R/W":        800 << 20,   // B/s
-}
-
-func Score(r benchmarks.Result) float64 {
-	ref, ok := Reference[r.Name]
+// Score returns the sub-score for r against set; tests that did not pass
+// score 0 and are thereby left out of Summarize.
+func (set *RefSet) Score(r benchmarks.Result) float64 {
+	if r.Status != benchmarks.StatusPassed || r.Err != "" {
+		return 0
+	}
+	ref, ok := set.References[r.Name]
 	if !ok || ref <= 0 {
 		return 0
 	}
-	var tp float64
-	switch r.Unit {
-	case "B/s":
-		if r.Duration.Seconds() > 0 {
-			tp = float64(r.Bytes) / r.Duration.Seconds()
-		}
-	case "GFLOP/s":
-		tp = float64(r.Ops) / 1e6
-	default:
-		tp = float64(r.Ops)
+	// references are in the same base units as Throughput.Value
+	tp := r.Throughput.Value
+	if tp > 0 && r.Throughput.Unit.LowerIsBetter() {
+		// latency: invert so that faster memory scores higher
+		tp = ref * ref / tp
 	}
 	if tp <= 0 {
 		return 0
 	}
-	return (tp / ref) * Baseline
+	return (tp / ref) * set.Baseline
 }
 
-func Aggregate(rs []benchmarks.Result) float64 {
-	prod := 1.0
-	n := 0
-	for _, r := range rs {
-		s := Score(r)
-		if s > 0 {
-			prod *= s
-			n++
-		}
-	}
-	if n == 0 {
+// EfficiencyScore scores r's work per joule: a result matching both the
+// reference throughput and the reference power scores Baseline. It is 0
+// when either power figure is unknown.
+func (set *RefSet) EfficiencyScore(r benchmarks.Result) float64 {
+	if r.Energy == nil || r.Energy.Watts <= 0 || set.Watts <= 0 {
 		return 0
 	}
-	return math.Pow(prod, 1.0/float64(n))
+	return set.Score(r) * set.Watts / r.Energy.Watts
 }
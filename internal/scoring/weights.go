@@ -0,0 +1,222 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package scoring
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"slices"
+
+	"github.com/e1z0/Benchy/internal/benchmarks"
+)
+
+// Sections lists the scored sections in display order and the tests each
+// one is made of. Tests not listed here are unscored.
+var Sections = []struct {
+	Name  string
+	Tests []string
+}{
+	{"CPU", []string{"CPU SHA-256", "AES-CTR", "SHA-512", "BLAKE2b", "AES-GCM", "ChaCha20-Poly1305",
+		"ECDSA P-256 Sign", "ECDSA P-256 Verify", "Ed25519 Sign", "Ed25519 Verify",
+		"Zstd Compress", "Gzip Compress", "Zstd Decompress", "Gzip Decompress", "JSON Parse", "JSON Parse Map", "JSON Encode",
+		"Sort", "Regex", "Map ops", "Dijkstra", "MatMul", "MatMul FP32", "FFT", "N-body", "Mandelbrot"}},
+	{"Memory", []string{"Memory copy", "Memory latency", "STREAM Copy", "STREAM Scale", "STREAM Add", "STREAM Triad"}},
+	{"Storage", []string{"Disk seq R/W", "Disk metadata"}},
+	{"Image", []string{"Gaussian Blur 1080p", "PNG Encode", "PNG Decode", "JPEG Encode", "JPEG Decode",
+		"Resize Bilinear", "Resize Lanczos", "YCbCr Convert", "Sobel"}},
+	{"Runtime", []string{"Alloc rate", "GC pressure", "Chan ping-pong", "Mutex contention"}},
+}
+
+// SectionOf returns the section test belongs to, or "" if it is unscored.
+func SectionOf(test string) string {
+	for _, s := range Sections {
+		for _, t := range s.Tests {
+			if t == test {
+				return s.Name
+			}
+		}
+	}
+	return ""
+}
+
+// Weights sets how much each test counts within its section and how much
+// each section counts towards the overall score. Missing entries weigh 1;
+// a weight of 0 leaves the test or section out.
+type Weights struct {
+	Name     string             `json:"name"`
+	Sections map[string]float64 `json:"sections,omitempty"`
+	Tests    map[string]float64 `json:"tests,omitempty"`
+}
+
+// DefaultWeights names the preset used unless the user picks another.
+const DefaultWeights = "balanced"
+
+// WeightPresets are the built-in weightings.
+var WeightPresets = []Weights{
+	{Name: "balanced"},
+	{Name: "compute",
+		Sections: map[string]float64{"CPU": 3, "Memory": 1.5, "Storage": 0.5, "Image": 1.5, "Runtime": 1},
+		Tests:    map[string]float64{"MatMul": 2, "MatMul FP32": 2, "FFT": 2, "N-body": 2}},
+	{Name: "storage-heavy",
+		Sections: map[string]float64{"CPU": 1, "Memory": 1, "Storage": 4, "Image": 0.5, "Runtime": 0.5},
+		Tests:    map[string]float64{"Disk metadata": 2}},
+}
+
+// LookupWeights returns the preset called name, or balanced if unknown.
+func LookupWeights(name string) Weights {
+	for _, w := range WeightPresets {
+		if w.Name == name {
+			return w
+		}
+	}
+	return WeightPresets[0]
+}
+
+// LoadWeights reads a user weighting in the same JSON form as exported.
+func LoadWeights(fn string) (Weights, error) {
+	var w Weights
+	b, err := os.ReadFile(fn)
+	if err != nil {
+		return w, err
+	}
+	if err := json.Unmarshal(b, &w); err != nil {
+		return w, err
+	}
+	if w.Name == "" {
+		return w, errors.New("weights need a name")
+	}
+	for _, m := range []map[string]float64{w.Sections, w.Tests} {
+		for k, v := range m {
+			if v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+				return w, errors.New("bad weight for " + k)
+			}
+		}
+	}
+	return w, nil
+}
+
+func (w Weights) Section(name string) float64 { return weight(w.Sections, name) }
+func (w Weights) Test(name string) float64    { return weight(w.Tests, name) }
+
+func weight(m map[string]float64, k string) float64 {
+	if v, ok := m[k]; ok {
+		return v
+	}
+	return 1
+}
+
+// Summary is the scored outcome of one suite run. Weights lists every
+// weight that was applied, defaults included, so exports are explicit.
+//
+// Scores are computed over the tests that passed and have a reference;
+// if any scored test did not pass, or passed with no reference in the
+// set, Incomplete is set, its section is listed and NotPassed or
+// Unreferenced names it, so a partial score is never mistaken for a full
+// one.
+type Summary struct {
+	Overall            float64            `json:"overall"`
+	Efficiency         float64            `json:"efficiency,omitempty"` // weighted like Overall, from EfficiencyScore
+	Sections           map[string]float64 `json:"sections"`
+	Weights            Weights            `json:"weights"`
+	Incomplete         bool               `json:"incomplete"`
+	IncompleteSections []string           `json:"incomplete_sections,omitempty"`
+	NotPassed          map[string]string  `json:"not_passed,omitempty"`
+	Unreferenced       []string           `json:"unreferenced,omitempty"`
+}
+
+// SectionIncomplete reports whether section lacks passing results.
+func (s Summary) SectionIncomplete(section string) bool {
+	return slices.Contains(s.IncompleteSections, section)
+}
+
+// Summarize scores rs against set: each section is the weighted
+// geometric mean of its tests' scores and the overall score is the
+// weighted geometric mean of the section scores. Tests and sections that
+// score 0 are left out; those that did not pass or have no reference also
+// mark it incomplete.
+func (set *RefSet) Summarize(rs []benchmarks.Result, w Weights) Summary {
+	sum := Summary{Sections: map[string]float64{}, Weights: Weights{
+		Name: w.Name, Sections: map[string]float64{}, Tests: map[string]float64{},
+	}}
+	per := map[string]*geoMean{}
+	eff := map[string]*geoMean{}
+	partial := map[string]bool{}
+	for _, r := range rs {
+		sec := SectionOf(r.Name)
+		if sec == "" {
+			continue
+		}
+		if r.Status != benchmarks.StatusPassed {
+			if sum.NotPassed == nil {
+				sum.NotPassed = map[string]string{}
+			}
+			sum.NotPassed[r.Name] = r.Status.String()
+			partial[sec] = true
+		} else if _, ok := set.References[r.Name]; !ok {
+			sum.Unreferenced = append(sum.Unreferenced, r.Name)
+			partial[sec] = true
+		}
+		if per[sec] == nil {
+			per[sec], eff[sec] = &geoMean{}, &geoMean{}
+		}
+		per[sec].add(set.Score(r), w.Test(r.Name))
+		eff[sec].add(set.EfficiencyScore(r), w.Test(r.Name))
+		sum.Weights.Tests[r.Name] = w.Test(r.Name)
+	}
+	var all, allEff geoMean
+	for _, s := range Sections {
+		g := per[s.Name]
+		if g == nil {
+			continue
+		}
+		if partial[s.Name] {
+			sum.IncompleteSections = append(sum.IncompleteSections, s.Name)
+		}
+		v := g.value()
+		sum.Sections[s.Name] = v
+		sum.Weights.Sections[s.Name] = w.Section(s.Name)
+		all.add(v, w.Section(s.Name))
+		allEff.add(eff[s.Name].value(), w.Section(s.Name))
+	}
+	sum.Overall = all.value()
+	sum.Efficiency = allEff.value()
+	sum.Incomplete = len(sum.NotPassed) > 0 || len(sum.Unreferenced) > 0
+	return sum
+}
+
+// geoMean accumulates a weighted geometric mean in log space.
+type geoMean struct{ logSum, wSum float64 }
+
+func (g *geoMean) add(v, w float64) {
+	if v > 0 && w > 0 {
+		g.logSum += w * math.Log(v)
+		g.wSum += w
+	}
+}
+
+func (g *geoMean) value() float64 {
+	if g.wSum == 0 {
+		return 0
+	}
+	return math.Exp(g.logSum / g.wSum)
+}
@@ -15,6 +15,7 @@ type Info struct {
 	PhysicalCores int    `json:"physical_cores"`  // where available (fallback 0 if unknown)
 	LogicalCPUs   int    `json:"logical_cpus"`    // NumCPU()
 	NominalFreqHz uint64 `json:"nominal_freq_hz"` // nominal/base frequency if known (0 if unknown)
+	LLCBytes      uint64 `json:"llc_bytes"`       // last-level cache size (0 if unknown)
 
 	// Machine / Board
 	MachineModel    string `json:"machine_model"`    // e.g., "MacBookPro16,1" or "Precision 3460"
@@ -26,6 +27,9 @@ type Info struct {
 
 	// Memory (physical)
 	TotalRAMBytes uint64 `json:"total_ram_bytes"`
+
+	// Hybrid core kinds (P/E or big/LITTLE), nil if all cores are alike
+	CoreClasses []CoreClass `json:"core_classes,omitempty"`
 }
 
 func Collect() Info {
@@ -49,6 +53,15 @@ func (i Info) String() string {
 	if i.TotalRAMBytes > 0 {
 		mem = fmt.Sprintf("\nMemory: %.1f GB", float64(i.TotalRAMBytes)/1073741824.0)
 	}
+	if i.LLCBytes > 0 {
+		mem += fmt.Sprintf("\nLast-level cache: %d MB", i.LLCBytes>>20)
+	}
+	for _, c := range i.CoreClasses {
+		mem += fmt.Sprintf("\nCore class %s: CPUs %s", c.Name, FormatCPUList(c.CPUs))
+		if c.MaxFreqKHz > 0 {
+			mem += fmt.Sprintf(" @ %.2f GHz", float64(c.MaxFreqKHz)/1e6)
+		}
+	}
 	model := i.MachineModel
 	if model == "" {
 		model = i.ProductName
@@ -47,6 +47,12 @@ func populateExtra(i *Info) {
 	// Nominal CPU frequency (Hz)
 	i.NominalFreqHz = uint64(sysctlInt64("hw.cpufrequency"))
 
+	// Last-level cache: Apple Silicon has no L3, its L2 is the LLC
+	i.LLCBytes = uint64(sysctlInt64("hw.l3cachesize"))
+	if i.LLCBytes == 0 {
+		i.LLCBytes = uint64(sysctlInt64("hw.l2cachesize"))
+	}
+
 	// Memory
 	i.TotalRAMBytes = uint64(sysctlInt64("hw.memsize"))
 
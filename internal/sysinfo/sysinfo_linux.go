@@ -25,6 +25,7 @@ package sysinfo
 
 import (
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 )
@@ -41,7 +42,11 @@ func populateExtra(i *Info) {
 		if i.CPUModel == "" {
 			i.CPUModel = firstKV(text, "Processor\t:")
 		}
-		// Physical cores is tricky on Linux; leave 0 if unknown.
+	}
+	// physical cores from the sysfs topology; left 0 if unreadable
+	if t, err := ReadTopology(DefaultSysfs); err == nil {
+		i.PhysicalCores = len(t.PhysicalCores())
+		i.CoreClasses = t.Classes()
 	}
 
 	// Nominal frequency: try /sys/devices/system/cpu/cpu0/cpufreq/cpuinfo_max_freq (kHz)
@@ -51,6 +56,8 @@ func populateExtra(i *Info) {
 		}
 	}
 
+	i.LLCBytes = lastLevelCache("/sys/devices/system/cpu/cpu0/cache")
+
 	// DMI / machine model & vendor
 	i.SystemVendor = readFirst("/sys/class/dmi/id/sys_vendor")
 	i.ProductName = readFirst("/sys/class/dmi/id/product_name")
@@ -77,11 +84,36 @@ func firstKV(text, key string) string {
 	return ""
 }
 
-func readFirst(path string) string {
-	if b, err := os.ReadFile(path); err == nil {
-		return strings.TrimSpace(string(b))
+// lastLevelCache returns the size of the highest-level data/unified cache
+// listed under dir (the cpuN/cache sysfs directory).
+func lastLevelCache(dir string) uint64 {
+	idx, _ := filepath.Glob(filepath.Join(dir, "index*"))
+	var best uint64
+	bestLevel := 0
+	for _, d := range idx {
+		if readFirst(filepath.Join(d, "type")) == "Instruction" {
+			continue
+		}
+		level, _ := strconv.Atoi(readFirst(filepath.Join(d, "level")))
+		size := parseCacheSize(readFirst(filepath.Join(d, "size")))
+		if level > bestLevel || (level == bestLevel && size > best) {
+			best, bestLevel = size, level
+		}
+	}
+	return best
+}
+
+// parseCacheSize parses sysfs cache sizes such as "32K" or "8M".
+func parseCacheSize(s string) uint64 {
+	mult := uint64(1)
+	switch {
+	case strings.HasSuffix(s, "K"):
+		mult, s = 1<<10, strings.TrimSuffix(s, "K")
+	case strings.HasSuffix(s, "M"):
+		mult, s = 1<<20, strings.TrimSuffix(s, "M")
 	}
-	return ""
+	v, _ := strconv.ParseUint(s, 10, 64)
+	return v * mult
 }
 
 func parseMemKB(meminfo string) (uint64, error) {
@@ -33,6 +33,8 @@ type win32_Processor struct {
 	NumberOfCores             uint32
 	NumberOfLogicalProcessors uint32
 	MaxClockSpeed             uint32 // MHz (approx base clock)
+	L2CacheSize               uint32 // KB
+	L3CacheSize               uint32 // KB
 }
 type win32_ComputerSystem struct {
 	Manufacturer        string
@@ -49,7 +51,7 @@ type win32_BIOS struct {
 func populateExtra(i *Info) {
 	// CPU
 	var cpus []win32_Processor
-	_ = wmi.Query("SELECT Name, Manufacturer, NumberOfCores, NumberOfLogicalProcessors, MaxClockSpeed FROM Win32_Processor", &cpus)
+	_ = wmi.Query("SELECT Name, Manufacturer, NumberOfCores, NumberOfLogicalProcessors, MaxClockSpeed, L2CacheSize, L3CacheSize FROM Win32_Processor", &cpus)
 	if len(cpus) > 0 {
 		c := cpus[0]
 		i.CPUModel = c.Name
@@ -63,6 +65,11 @@ func populateExtra(i *Info) {
 		if c.MaxClockSpeed > 0 {
 			i.NominalFreqHz = uint64(c.MaxClockSpeed) * 1_000_000 // MHz -> Hz
 		}
+		if c.L3CacheSize > 0 {
+			i.LLCBytes = uint64(c.L3CacheSize) << 10
+		} else {
+			i.LLCBytes = uint64(c.L2CacheSize) << 10
+		}
 	}
 
 	// System
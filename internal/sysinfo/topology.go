@@ -0,0 +1,328 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package sysinfo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DefaultSysfs is where sysfs is mounted. The topology readers take the
+// root as an argument so they can be pointed at a fake tree.
+const DefaultSysfs = "/sys"
+
+// CPU is one logical CPU as the kernel numbers it.
+type CPU struct {
+	ID      int `json:"id"`
+	Core    int `json:"core"`    // core_id, unique within a package
+	Package int `json:"package"` // physical_package_id
+	Node    int `json:"node"`    // NUMA node, 0 without NUMA
+
+	// hybrid-core hints, 0 or "" when the kernel doesn't expose them
+	Type       string `json:"type,omitempty"`         // "P-core"/"E-core" from cpu_core/cpu_atom
+	Capacity   int    `json:"capacity,omitempty"`     // cpu_capacity, 1024 for the fastest core
+	MaxFreqKHz int    `json:"max_freq_khz,omitempty"` // cpufreq cpuinfo_max_freq
+}
+
+// CoreClass is a group of CPUs of the same kind on a hybrid machine,
+// e.g. the P-cores of an Intel hybrid part or the big cluster of an ARM
+// big.LITTLE SoC.
+type CoreClass struct {
+	Name       string `json:"name"`
+	CPUs       []int  `json:"cpus"`
+	Capacity   int    `json:"capacity,omitempty"`
+	MaxFreqKHz int    `json:"max_freq_khz,omitempty"`
+}
+
+// Representative is the CPU single-threaded tests of the class pin to.
+func (c CoreClass) Representative() int { return c.CPUs[0] }
+
+// Topology is the layout of the online CPUs. It is only read on Linux;
+// elsewhere ReadTopology fails and callers fall back to the OS scheduler.
+type Topology struct {
+	CPUs []CPU `json:"cpus"`
+}
+
+// ReadTopology reads the online CPUs and their core, package and node
+// from root/devices/system.
+func ReadTopology(root string) (Topology, error) {
+	sys := filepath.Join(root, "devices", "system")
+	online, err := os.ReadFile(filepath.Join(sys, "cpu", "online"))
+	if err != nil {
+		return Topology{}, err
+	}
+	ids, err := ParseCPUList(string(online))
+	if err != nil {
+		return Topology{}, err
+	}
+	nodeOf := map[int]int{}
+	nodes, _ := filepath.Glob(filepath.Join(sys, "node", "node[0-9]*"))
+	for _, d := range nodes {
+		n, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(d), "node"))
+		if err != nil {
+			continue
+		}
+		cpus, _ := ParseCPUList(readFirst(filepath.Join(d, "cpulist")))
+		for _, c := range cpus {
+			nodeOf[c] = n
+		}
+	}
+	// Intel hybrid parts register one PMU per core type
+	typeOf := map[int]string{}
+	for _, pmu := range []struct{ dir, name string }{{"cpu_core", "P-core"}, {"cpu_atom", "E-core"}} {
+		cpus, _ := ParseCPUList(readFirst(filepath.Join(root, "devices", pmu.dir, "cpus")))
+		for _, c := range cpus {
+			typeOf[c] = pmu.name
+		}
+	}
+	var t Topology
+	for _, id := range ids {
+		cpuDir := filepath.Join(sys, "cpu", fmt.Sprintf("cpu%d", id))
+		dir := filepath.Join(cpuDir, "topology")
+		c := CPU{ID: id, Core: id, Node: nodeOf[id], Type: typeOf[id]}
+		c.Capacity, _ = strconv.Atoi(readFirst(filepath.Join(cpuDir, "cpu_capacity")))
+		c.MaxFreqKHz, _ = strconv.Atoi(readFirst(filepath.Join(cpuDir, "cpufreq", "cpuinfo_max_freq")))
+		// without topology files every CPU counts as its own core
+		if v, err := strconv.Atoi(readFirst(filepath.Join(dir, "core_id"))); err == nil {
+			c.Core = v
+		}
+		if v, err := strconv.Atoi(readFirst(filepath.Join(dir, "physical_package_id"))); err == nil {
+			c.Package = v
+		}
+		t.CPUs = append(t.CPUs, c)
+	}
+	return t, nil
+}
+
+// PhysicalCores returns the lowest-numbered CPU of each physical core,
+// i.e. one SMT sibling per core.
+func (t Topology) PhysicalCores() []int {
+	seen := map[[2]int]bool{}
+	var out []int
+	for _, c := range t.CPUs {
+		k := [2]int{c.Package, c.Core}
+		if !seen[k] {
+			seen[k] = true
+			out = append(out, c.ID)
+		}
+	}
+	return out
+}
+
+// Classes groups the CPUs by core kind, fastest first. It uses the core
+// type where the kernel reports one, else capacity (ARM), else maximum
+// frequency, where only a gap of freqGap or more separates two classes.
+// It returns nil on machines with a single kind of core.
+func (t Topology) Classes() []CoreClass {
+	type key struct {
+		typ       string
+		cap, freq int
+	}
+	var keyOf func(CPU) key
+	switch {
+	case t.varies(func(c CPU) any { return c.Type }, ""):
+		keyOf = func(c CPU) key { return key{typ: c.Type} }
+	case t.varies(func(c CPU) any { return c.Capacity }, 0):
+		keyOf = func(c CPU) key { return key{cap: c.Capacity} }
+	case t.varies(func(c CPU) any { return c.MaxFreqKHz }, 0):
+		top := t.freqClusters()
+		keyOf = func(c CPU) key { return key{freq: top[c.MaxFreqKHz]} }
+	default:
+		return nil
+	}
+	idx := map[key]int{}
+	var out []CoreClass
+	for _, c := range t.CPUs {
+		k := keyOf(c)
+		i, ok := idx[k]
+		if !ok {
+			i = len(out)
+			idx[k] = i
+			out = append(out, CoreClass{Name: k.typ})
+		}
+		cl := &out[i]
+		cl.CPUs = append(cl.CPUs, c.ID)
+		cl.Capacity = max(cl.Capacity, c.Capacity)
+		cl.MaxFreqKHz = max(cl.MaxFreqKHz, c.MaxFreqKHz)
+	}
+	if len(out) < 2 {
+		return nil
+	}
+	slices.SortStableFunc(out, func(a, b CoreClass) int {
+		if a.Name != b.Name {
+			// P-core sorts before E-core
+			return strings.Compare(b.Name, a.Name)
+		}
+		if a.Capacity != b.Capacity {
+			return b.Capacity - a.Capacity
+		}
+		return b.MaxFreqKHz - a.MaxFreqKHz
+	})
+	names := []string{"big", "LITTLE"}
+	if len(out) == 3 {
+		names = []string{"prime", "big", "LITTLE"}
+	}
+	for i := range out {
+		switch {
+		case out[i].Name != "":
+		case len(out) <= 3:
+			out[i].Name = names[i]
+		case out[i].Capacity > 0:
+			out[i].Name = fmt.Sprintf("capacity %d", out[i].Capacity)
+		default:
+			out[i].Name = fmt.Sprintf("%d MHz", out[i].MaxFreqKHz/1000)
+		}
+	}
+	return out
+}
+
+// freqGap is how far below the next faster one a maximum frequency must
+// be to start a new class; cores of one kind often differ by a boost bin
+// or two.
+const freqGap = 0.15
+
+// freqClusters maps each maximum frequency to the highest frequency of
+// its cluster, splitting where consecutive frequencies are freqGap apart.
+func (t Topology) freqClusters() map[int]int {
+	seen := map[int]bool{}
+	var fs []int
+	for _, c := range t.CPUs {
+		if !seen[c.MaxFreqKHz] {
+			seen[c.MaxFreqKHz] = true
+			fs = append(fs, c.MaxFreqKHz)
+		}
+	}
+	slices.Sort(fs)
+	slices.Reverse(fs)
+	top := map[int]int{}
+	for i, f := range fs {
+		if i > 0 && float64(f) > float64(fs[i-1])*(1-freqGap) {
+			top[f] = top[fs[i-1]]
+		} else {
+			top[f] = f
+		}
+	}
+	return top
+}
+
+// varies reports whether f is known for every CPU and takes more than
+// one value.
+func (t Topology) varies(f func(CPU) any, unknown any) bool {
+	seen := map[any]bool{}
+	for _, c := range t.CPUs {
+		v := f(c)
+		if v == unknown {
+			return false
+		}
+		seen[v] = true
+	}
+	return len(seen) > 1
+}
+
+// Nodes returns the NUMA node numbers that have online CPUs.
+func (t Topology) Nodes() []int {
+	var out []int
+	for _, c := range t.CPUs {
+		if !slices.Contains(out, c.Node) {
+			out = append(out, c.Node)
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
+// NodeCPUs returns the online CPUs of NUMA node n.
+func (t Topology) NodeCPUs(n int) []int {
+	var out []int
+	for _, c := range t.CPUs {
+		if c.Node == n {
+			out = append(out, c.ID)
+		}
+	}
+	return out
+}
+
+// MaxCPUs bounds the CPU numbers ParseCPUList accepts, so a mistyped
+// range can't expand to millions of entries.
+const MaxCPUs = 4096
+
+// ParseCPUList parses the kernel's CPU list format, e.g. "0-3,8,10-11".
+// Duplicates are dropped; CPU numbers must be below MaxCPUs.
+func ParseCPUList(s string) ([]int, error) {
+	var out []int
+	seen := map[int]bool{}
+	for _, part := range strings.Split(strings.TrimSpace(s), ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		lo, hi, isRange := strings.Cut(part, "-")
+		a, err := strconv.Atoi(lo)
+		if err != nil || a < 0 || a >= MaxCPUs {
+			return nil, fmt.Errorf("bad CPU list %q", s)
+		}
+		b := a
+		if isRange {
+			if b, err = strconv.Atoi(hi); err != nil || b < a || b >= MaxCPUs {
+				return nil, fmt.Errorf("bad CPU list %q", s)
+			}
+		}
+		for c := a; c <= b; c++ {
+			if !seen[c] {
+				seen[c] = true
+				out = append(out, c)
+			}
+		}
+	}
+	return out, nil
+}
+
+// FormatCPUList is the inverse of ParseCPUList, collapsing runs.
+func FormatCPUList(cpus []int) string {
+	s := slices.Clone(cpus)
+	slices.Sort(s)
+	var parts []string
+	for i := 0; i < len(s); {
+		j := i
+		for j+1 < len(s) && s[j+1] == s[j]+1 {
+			j++
+		}
+		if j > i {
+			parts = append(parts, fmt.Sprintf("%d-%d", s[i], s[j]))
+		} else {
+			parts = append(parts, strconv.Itoa(s[i]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
+
+// readFirst returns the trimmed contents of path, or "" if unreadable.
+func readFirst(path string) string {
+	if b, err := os.ReadFile(path); err == nil {
+		return strings.TrimSpace(string(b))
+	}
+	return ""
+}
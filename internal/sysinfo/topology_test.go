@@ -0,0 +1,253 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package sysinfo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fakeSysfs writes files (path relative to root → contents) under a temp
+// root and returns it.
+func fakeSysfs(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for p, v := range files {
+		full := filepath.Join(root, p)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(v+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+// cpuFiles describes cpuN with the given core and package ids plus any
+// extra per-CPU files (relative to cpuN).
+func cpuFiles(files map[string]string, id, core, pkg int, extra map[string]string) {
+	dir := fmt.Sprintf("devices/system/cpu/cpu%d", id)
+	files[dir+"/topology/core_id"] = fmt.Sprint(core)
+	files[dir+"/topology/physical_package_id"] = fmt.Sprint(pkg)
+	for k, v := range extra {
+		files[dir+"/"+k] = v
+	}
+}
+
+func TestParseCPUList(t *testing.T) {
+	for _, tc := range []struct {
+		in      string
+		want    []int
+		wantErr bool
+	}{
+		{"0", []int{0}, false},
+		{"0-3\n", []int{0, 1, 2, 3}, false},
+		{"0-3,8,10-11", []int{0, 1, 2, 3, 8, 10, 11}, false},
+		{" 4 , 2-3 ", []int{4, 2, 3}, false},
+		{"1,1,0-2", []int{1, 0, 2}, false},
+		{"", nil, false},
+		{"3-1", nil, true},
+		{"a", nil, true},
+		{"-1", nil, true},
+		{"0-", nil, true},
+		{"1-2-3", nil, true},
+		{"0-999999999", nil, true},
+		{fmt.Sprint(MaxCPUs), nil, true},
+	} {
+		got, err := ParseCPUList(tc.in)
+		if (err != nil) != tc.wantErr || !slices.Equal(got, tc.want) {
+			t.Errorf("ParseCPUList(%q) = %v, %v; want %v, err %v", tc.in, got, err, tc.want, tc.wantErr)
+		}
+	}
+}
+
+func TestFormatCPUList(t *testing.T) {
+	for _, tc := range []struct {
+		in   []int
+		want string
+	}{
+		{nil, ""},
+		{[]int{5}, "5"},
+		{[]int{3, 0, 1, 2}, "0-3"},
+		{[]int{0, 1, 2, 3, 8, 10, 11}, "0-3,8,10-11"},
+	} {
+		if got := FormatCPUList(tc.in); got != tc.want {
+			t.Errorf("FormatCPUList(%v) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestReadTopology(t *testing.T) {
+	// two packages, two cores each with SMT siblings, one node per
+	// package; cpu3 is offline and must be left out
+	files := map[string]string{
+		"devices/system/cpu/online":         "0-2,4-7",
+		"devices/system/node/node0/cpulist": "0-3",
+		"devices/system/node/node1/cpulist": "4-7",
+	}
+	for id := 0; id < 8; id++ {
+		cpuFiles(files, id, id%2, id/4, nil)
+	}
+	tp, err := ReadTopology(fakeSysfs(t, files))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []int
+	for _, c := range tp.CPUs {
+		ids = append(ids, c.ID)
+	}
+	if want := []int{0, 1, 2, 4, 5, 6, 7}; !slices.Equal(ids, want) {
+		t.Errorf("online CPUs = %v, want %v", ids, want)
+	}
+	if got, want := tp.PhysicalCores(), []int{0, 1, 4, 5}; !slices.Equal(got, want) {
+		t.Errorf("PhysicalCores = %v, want %v", got, want)
+	}
+	if got, want := tp.Nodes(), []int{0, 1}; !slices.Equal(got, want) {
+		t.Errorf("Nodes = %v, want %v", got, want)
+	}
+	if got, want := tp.NodeCPUs(1), []int{4, 5, 6, 7}; !slices.Equal(got, want) {
+		t.Errorf("NodeCPUs(1) = %v, want %v", got, want)
+	}
+}
+
+func TestReadTopologyFallbacks(t *testing.T) {
+	// no topology or node files: every CPU is its own core on node 0
+	tp, err := ReadTopology(fakeSysfs(t, map[string]string{"devices/system/cpu/online": "0-1"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := tp.PhysicalCores(); !slices.Equal(got, []int{0, 1}) {
+		t.Errorf("PhysicalCores = %v", got)
+	}
+	if got := tp.Nodes(); !slices.Equal(got, []int{0}) {
+		t.Errorf("Nodes = %v", got)
+	}
+
+	for name, files := range map[string]map[string]string{
+		"missing online":   {},
+		"malformed online": {"devices/system/cpu/online": "0-x"},
+		"reversed online":  {"devices/system/cpu/online": "3-0"},
+	} {
+		if _, err := ReadTopology(fakeSysfs(t, files)); err == nil {
+			t.Errorf("%s: ReadTopology succeeded", name)
+		} else if name != "missing online" && !strings.Contains(err.Error(), "bad CPU list") {
+			t.Errorf("%s: err = %v", name, err)
+		}
+	}
+}
+
+func TestClasses(t *testing.T) {
+	type class struct {
+		name string
+		cpus []int
+	}
+	hybrid := func(online, core, atom string) map[string]string {
+		// cpu0-3 are two SMT P-cores, cpu4-7 four E-cores
+		files := map[string]string{
+			"devices/system/cpu/online": online,
+			"devices/cpu_core/cpus":     core,
+			"devices/cpu_atom/cpus":     atom,
+		}
+		for id := 0; id < 8; id++ {
+			core := id / 2
+			if id >= 4 {
+				core = id
+			}
+			cpuFiles(files, id, core, 0, map[string]string{"cpufreq/cpuinfo_max_freq": "4000000"})
+		}
+		return files
+	}
+	arm := func(caps ...string) map[string]string {
+		files := map[string]string{"devices/system/cpu/online": fmt.Sprintf("0-%d", len(caps)-1)}
+		for id, c := range caps {
+			cpuFiles(files, id, id, 0, map[string]string{"cpu_capacity": c})
+		}
+		return files
+	}
+	freqs := func(khz ...string) map[string]string {
+		files := map[string]string{"devices/system/cpu/online": fmt.Sprintf("0-%d", len(khz)-1)}
+		for id, f := range khz {
+			cpuFiles(files, id, id, 0, map[string]string{"cpufreq/cpuinfo_max_freq": f})
+		}
+		return files
+	}
+	for _, tc := range []struct {
+		name  string
+		files map[string]string
+		want  []class
+	}{
+		{"hybrid", hybrid("0-7", "0-3", "4-7"),
+			[]class{{"P-core", []int{0, 1, 2, 3}}, {"E-core", []int{4, 5, 6, 7}}}},
+		{"hybrid offline", hybrid("0-1,4-5", "0-3", "4-7"),
+			[]class{{"P-core", []int{0, 1}}, {"E-core", []int{4, 5}}}},
+		{"hybrid all E-cores offline", hybrid("0-3", "0-3", "4-7"), nil},
+		{"hybrid malformed PMU list", hybrid("0-7", "0-3", "4-x"), nil},
+		{"big.LITTLE", arm("446", "446", "1024", "1024"),
+			[]class{{"big", []int{2, 3}}, {"LITTLE", []int{0, 1}}}},
+		{"prime/big/LITTLE", arm("325", "325", "870", "870", "1024"),
+			[]class{{"prime", []int{4}}, {"big", []int{2, 3}}, {"LITTLE", []int{0, 1}}}},
+		{"partial capacity", arm("446", "", "1024"), nil},
+		{"frequency only", freqs("2000000", "3000000", "3000000"),
+			[]class{{"big", []int{1, 2}}, {"LITTLE", []int{0}}}},
+		{"frequency boost bins", freqs("2000000", "4500000", "4600000"),
+			[]class{{"big", []int{1, 2}}, {"LITTLE", []int{0}}}},
+		{"near-equal frequencies", freqs("4400000", "4500000", "4600000"), nil},
+		{"homogeneous", arm("1024", "1024"), nil},
+	} {
+		tp, err := ReadTopology(fakeSysfs(t, tc.files))
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		var got []class
+		for _, cl := range tp.Classes() {
+			got = append(got, class{cl.Name, cl.CPUs})
+		}
+		if !slices.EqualFunc(got, tc.want, func(a, b class) bool {
+			return a.name == b.name && slices.Equal(a.cpus, b.cpus)
+		}) {
+			t.Errorf("%s: Classes = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestClassRepresentative(t *testing.T) {
+	files := map[string]string{
+		"devices/system/cpu/online": "0-3",
+		"devices/cpu_core/cpus":     "2-3",
+		"devices/cpu_atom/cpus":     "0-1",
+	}
+	for id := 0; id < 4; id++ {
+		cpuFiles(files, id, id, 0, nil)
+	}
+	tp, err := ReadTopology(fakeSysfs(t, files))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cls := tp.Classes()
+	if len(cls) != 2 || cls[0].Name != "P-core" || cls[0].Representative() != 2 || cls[1].Representative() != 0 {
+		t.Errorf("Classes = %+v", cls)
+	}
+}
@@ -0,0 +1,169 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package ui
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/e1z0/Benchy/internal/benchmarks"
+
+	"github.com/mappu/miqt/qt"
+)
+
+// HistogramChart plots a latency histogram folded into power-of-two
+// buckets, with the usual percentiles in the header.
+type HistogramChart struct {
+	*qt.QWidget
+	title      string
+	data       []benchmarks.HistBucket
+	p50        time.Duration
+	p99        time.Duration
+	p999       time.Duration
+	max        uint64
+	hoverIndex int
+}
+
+func NewHistogramChart(parent *qt.QWidget) *HistogramChart {
+	w := qt.NewQWidget(parent)
+	hc := &HistogramChart{QWidget: w, hoverIndex: -1}
+	w.SetMouseTracking(true)
+	w.SetMinimumHeight(200)
+
+	w.OnMouseMoveEvent(func(super func(*qt.QMouseEvent), e *qt.QMouseEvent) {
+		idx := hc.hitTest(e.Pos().X(), e.Pos().Y())
+		if idx != hc.hoverIndex {
+			hc.hoverIndex = idx
+			hc.Update()
+		}
+	})
+
+	w.OnLeaveEvent(func(super func(*qt.QEvent), e *qt.QEvent) {
+		hc.hoverIndex = -1
+		hc.Update()
+	})
+
+	w.OnPaintEvent(func(super func(*qt.QPaintEvent), e *qt.QPaintEvent) {
+		p := qt.NewQPainter()
+		if !p.Begin(w.QPaintDevice) {
+			return
+		}
+		defer p.End()
+
+		r := w.Rect()
+		if len(hc.data) == 0 || hc.max == 0 {
+			text := "No latency data"
+			if hc.title != "" {
+				text = hc.title + ": no latency data"
+			}
+			p.DrawText6(r, int(qt.AlignCenter), text)
+			return
+		}
+
+		chart, base := hc.layout()
+		p.DrawText7(base.X(), base.Y(), base.Width(), 16, int(qt.AlignLeft|qt.AlignVCenter),
+			fmt.Sprintf("%s — p50 %s · p99 %s · p99.9 %s", hc.title, benchmarks.HumanDuration(hc.p50), benchmarks.HumanDuration(hc.p99), benchmarks.HumanDuration(hc.p999)))
+
+		p.DrawRectWithRect(chart)
+		y0 := chart.Y() + chart.Height()
+
+		n := len(hc.data)
+		gap := 4
+		barW := int(math.Max(1, float64(chart.Width()-gap*(n+1))/float64(n)))
+		x := chart.X() + gap
+		for i, b := range hc.data {
+			h := int(float64(chart.Height()) * float64(b.Count) / float64(hc.max))
+			rect := qt.NewQRect4(x, y0-h, barW, h)
+			if i == hc.hoverIndex {
+				p.FillRect3(rect, qt.NewQBrush11(qt.NewQColor3(255, 210, 160), 1))
+			} else {
+				p.FillRect3(rect, qt.NewQBrush11(qt.NewQColor3(220, 150, 90), 1))
+			}
+			// label every other bucket so the axis stays readable
+			if i%2 == 0 {
+				p.DrawText7(x-barW/2, y0+2, barW*2, 16, int(qt.AlignHCenter|qt.AlignTop), benchmarks.HumanDuration(b.Lower))
+			}
+			x += barW + gap
+		}
+
+		if hc.hoverIndex >= 0 && hc.hoverIndex < n {
+			b := hc.data[hc.hoverIndex]
+			text := fmt.Sprintf("%s – %s: %d", benchmarks.HumanDuration(b.Lower), benchmarks.HumanDuration(b.Upper), b.Count)
+			wtxt := p.FontMetrics().HorizontalAdvance(text) + 12
+			rect := qt.NewQRect4(chart.X()+8, chart.Y()+8, wtxt, 22)
+			p.FillRect3(rect, qt.NewQBrush11(qt.NewQColor3(50, 50, 50), 1))
+			p.SetPen(qt.NewQColor3(230, 230, 230))
+			p.DrawText6(rect, int(qt.AlignCenter), text)
+			p.SetPen(qt.NewQColor3(0, 0, 0))
+		}
+	})
+	return hc
+}
+
+// SetHistogram shows h under the given title; a nil h clears the view.
+func (hc *HistogramChart) SetHistogram(title string, h *benchmarks.Histogram) {
+	hc.title = title
+	hc.data = nil
+	hc.max = 0
+	if h != nil && h.Count() > 0 {
+		hc.data = h.Octaves()
+		hc.p50 = h.Percentile(50)
+		hc.p99 = h.Percentile(99)
+		hc.p999 = h.Percentile(99.9)
+		for _, b := range hc.data {
+			if b.Count > hc.max {
+				hc.max = b.Count
+			}
+		}
+	}
+	hc.hoverIndex = -1
+	hc.Update()
+}
+
+func (hc *HistogramChart) layout() (chart, base *qt.QRect) {
+	r := hc.Rect()
+	margin := 12
+	base = qt.NewQRect4(r.X()+margin, r.Y()+margin, r.Width()-2*margin, r.Height()-2*margin)
+	headerH := 20
+	labelH := 18
+	chart = qt.NewQRect4(base.X(), base.Y()+headerH, base.Width(), base.Height()-headerH-labelH)
+	return chart, base
+}
+
+func (hc *HistogramChart) hitTest(x, y int) int {
+	n := len(hc.data)
+	if n == 0 {
+		return -1
+	}
+	chart, _ := hc.layout()
+	gap := 4
+	barW := int(math.Max(1, float64(chart.Width()-gap*(n+1))/float64(n)))
+	bx := chart.X() + gap
+	for i := 0; i < n; i++ {
+		br := qt.NewQRect4(bx, chart.Y(), barW, chart.Height())
+		if br.Contains3(x, y, false) {
+			return i
+		}
+		bx += barW + gap
+	}
+	return -1
+}
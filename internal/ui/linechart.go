@@ -0,0 +1,281 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package ui
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/e1z0/Benchy/internal/benchmarks"
+
+	"github.com/mappu/miqt/qt"
+)
+
+// LineChart plots a benchmarks.Series as connected points, with an
+// optional log2 X axis for size sweeps, or as a labelled scatter plot.
+type LineChart struct {
+	*qt.QWidget
+	title      string
+	series     *benchmarks.Series
+	hoverIndex int
+}
+
+func NewLineChart(parent *qt.QWidget) *LineChart {
+	w := qt.NewQWidget(parent)
+	lc := &LineChart{QWidget: w, hoverIndex: -1}
+	w.SetMouseTracking(true)
+	w.SetMinimumHeight(200)
+
+	w.OnMouseMoveEvent(func(super func(*qt.QMouseEvent), e *qt.QMouseEvent) {
+		idx := lc.hitTest(e.Pos().X(), e.Pos().Y())
+		if idx != lc.hoverIndex {
+			lc.hoverIndex = idx
+			lc.Update()
+		}
+	})
+
+	w.OnLeaveEvent(func(super func(*qt.QEvent), e *qt.QEvent) {
+		lc.hoverIndex = -1
+		lc.Update()
+	})
+
+	w.OnPaintEvent(func(super func(*qt.QPaintEvent), e *qt.QPaintEvent) {
+		p := qt.NewQPainter()
+		if !p.Begin(w.QPaintDevice) {
+			return
+		}
+		defer p.End()
+
+		r := w.Rect()
+		if lc.series == nil || len(lc.series.Points) == 0 {
+			p.DrawText6(r, int(qt.AlignCenter), "No data")
+			return
+		}
+		s := lc.series
+		chart, base := lc.layout()
+		p.DrawText7(base.X(), base.Y(), base.Width(), 16, int(qt.AlignLeft|qt.AlignVCenter),
+			fmt.Sprintf("%s — %s vs %s", lc.title, s.YLabel, s.XLabel))
+		p.DrawRectWithRect(chart)
+
+		_, maxY := lc.yRange()
+		y0 := chart.Y() + chart.Height()
+		ticks := 5
+		for i := 0; i <= ticks; i++ {
+			t := float64(i) / float64(ticks)
+			y := y0 - int(t*float64(chart.Height()))
+			p.DrawLine2(chart.X(), y, chart.X()+chart.Width(), y)
+			p.DrawText7(chart.X()-50, y-8, 46, 16, int(qt.AlignRight|qt.AlignVCenter), fmtAxis(t*maxY))
+		}
+
+		pts := lc.project()
+		p.SetPen(qt.NewQColor3(120, 170, 220))
+		for i := 1; i < len(pts) && !s.Scatter; i++ {
+			p.DrawLine2(pts[i-1][0], pts[i-1][1], pts[i][0], pts[i][1])
+		}
+		for i, pt := range pts {
+			c := qt.NewQColor3(120, 170, 220)
+			if i == lc.hoverIndex {
+				c = qt.NewQColor3(180, 210, 255)
+			}
+			p.FillRect3(qt.NewQRect4(pt[0]-3, pt[1]-3, 7, 7), qt.NewQBrush11(c, 1))
+		}
+		p.SetPen(qt.NewQColor3(0, 0, 0))
+
+		if s.Scatter {
+			// numeric x ticks, point labels next to the points
+			minX, maxX := lc.xRange()
+			for i := 0; i <= ticks; i++ {
+				t := float64(i) / float64(ticks)
+				x := chart.X() + 8 + int(t*float64(chart.Width()-16))
+				p.DrawText7(x-40, y0+2, 80, 16, int(qt.AlignHCenter|qt.AlignTop), fmtAxis(minX+t*(maxX-minX)))
+			}
+			for i, pt := range pts {
+				p.DrawText7(pt[0]+6, pt[1]-18, 60, 16, int(qt.AlignLeft|qt.AlignBottom), s.Points[i].Label)
+			}
+		} else {
+			// x labels: thin out so they don't overlap
+			step := 1
+			if n := len(pts); n > 8 {
+				step = (n + 7) / 8
+			}
+			for i := 0; i < len(pts); i += step {
+				p.DrawText7(pts[i][0]-40, y0+2, 80, 16, int(qt.AlignHCenter|qt.AlignTop), lc.xLabel(i))
+			}
+		}
+
+		if lc.hoverIndex >= 0 && lc.hoverIndex < len(pts) {
+			text := fmt.Sprintf("%s: %s", lc.xLabel(lc.hoverIndex), fmtAxis(s.Points[lc.hoverIndex].Y))
+			wtxt := p.FontMetrics().HorizontalAdvance(text) + 12
+			rect := qt.NewQRect4(chart.X()+8, chart.Y()+8, wtxt, 22)
+			p.FillRect3(rect, qt.NewQBrush11(qt.NewQColor3(50, 50, 50), 1))
+			p.SetPen(qt.NewQColor3(230, 230, 230))
+			p.DrawText6(rect, int(qt.AlignCenter), text)
+			p.SetPen(qt.NewQColor3(0, 0, 0))
+		}
+	})
+	return lc
+}
+
+func (lc *LineChart) SetSeries(title string, s *benchmarks.Series) {
+	lc.title = title
+	lc.series = s
+	lc.hoverIndex = -1
+	lc.Update()
+}
+
+func (lc *LineChart) layout() (chart, base *qt.QRect) {
+	r := lc.Rect()
+	margin := 12
+	base = qt.NewQRect4(r.X()+margin, r.Y()+margin, r.Width()-2*margin, r.Height()-2*margin)
+	headerH := 20
+	labelH := 18
+	leftPad := 52
+	chart = qt.NewQRect4(base.X()+leftPad, base.Y()+headerH, base.Width()-leftPad-8, base.Height()-headerH-labelH)
+	return chart, base
+}
+
+func (lc *LineChart) yRange() (float64, float64) {
+	maxY := 0.0
+	for _, pt := range lc.series.Points {
+		maxY = math.Max(maxY, pt.Y)
+	}
+	if maxY <= 0 {
+		maxY = 1
+	}
+	return 0, maxY * 1.1
+}
+
+func (lc *LineChart) xval(v float64) float64 {
+	if lc.series.LogX && v > 0 {
+		return math.Log2(v)
+	}
+	return v
+}
+
+// xRange returns the X extent in axis units; scatter plots start at 0.
+func (lc *LineChart) xRange() (float64, float64) {
+	minX, maxX := math.Inf(1), math.Inf(-1)
+	for _, pt := range lc.series.Points {
+		x := lc.xval(pt.X)
+		minX = math.Min(minX, x)
+		maxX = math.Max(maxX, x)
+	}
+	if lc.series.Scatter {
+		minX, maxX = 0, maxX*1.1
+	}
+	return minX, maxX
+}
+
+// project maps the series points to widget coordinates.
+func (lc *LineChart) project() [][2]int {
+	chart, _ := lc.layout()
+	pts := lc.series.Points
+	minX, maxX := lc.xRange()
+	span := maxX - minX
+	if span <= 0 {
+		span = 1
+	}
+	_, maxY := lc.yRange()
+	out := make([][2]int, len(pts))
+	inner := float64(chart.Width() - 16)
+	for i, pt := range pts {
+		fx := (lc.xval(pt.X) - minX) / span
+		out[i][0] = chart.X() + 8 + int(fx*inner)
+		out[i][1] = chart.Y() + chart.Height() - int(pt.Y/maxY*float64(chart.Height()))
+	}
+	return out
+}
+
+func (lc *LineChart) xLabel(i int) string {
+	pt := lc.series.Points[i]
+	if pt.Label != "" && lc.series.Scatter {
+		return fmt.Sprintf("%s (%s %s)", pt.Label, fmtAxis(pt.X), lc.series.XLabel)
+	}
+	if pt.Label != "" {
+		return pt.Label
+	}
+	if lc.series.XBytes {
+		return fmtSize(pt.X)
+	}
+	return fmtAxis(pt.X)
+}
+
+func (lc *LineChart) hitTest(x, y int) int {
+	if lc.series == nil || len(lc.series.Points) == 0 {
+		return -1
+	}
+	best, bestD := -1, 8*8
+	for i, pt := range lc.project() {
+		dx, dy := pt[0]-x, pt[1]-y
+		if d := dx*dx + dy*dy; d <= bestD {
+			best, bestD = i, d
+		}
+	}
+	return best
+}
+
+func fmtSize(b float64) string {
+	suffix := []string{"B", "K", "M", "G", "T"}
+	i := 0
+	for b >= 1024 && i < len(suffix)-1 {
+		b /= 1024
+		i++
+	}
+	return fmt.Sprintf("%.0f%s", b, suffix[i])
+}
+
+func fmtAxis(v float64) string {
+	switch {
+	case v >= 1e9:
+		return fmt.Sprintf("%.1fG", v/1e9)
+	case v >= 1e6:
+		return fmt.Sprintf("%.1fM", v/1e6)
+	case v >= 1e4:
+		return fmt.Sprintf("%.0fk", v/1e3)
+	case v >= 100:
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.1f", v)
+}
+
+// SeriesTable lays a series out as table rows for the detail view.
+func SeriesTable(s *benchmarks.Series) (headers []string, rows [][]string) {
+	headers = []string{s.XLabel, s.YLabel}
+	labelled := false
+	for _, pt := range s.Points {
+		labelled = labelled || pt.Label != ""
+	}
+	if labelled {
+		headers = append([]string{""}, headers...)
+	}
+	for _, pt := range s.Points {
+		x := fmt.Sprintf("%.2f", pt.X)
+		if s.XBytes {
+			x = fmtSize(pt.X)
+		}
+		row := []string{x, fmt.Sprintf("%.2f", pt.Y)}
+		if labelled {
+			row = append([]string{pt.Label}, row...)
+		}
+		rows = append(rows, row)
+	}
+	return headers, rows
+}
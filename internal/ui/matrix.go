@@ -0,0 +1,52 @@
+/* SPDX-License-Identifier: GPL-3.0-or-later
+ *
+ * Benchy
+ * Copyright (C) 2025 e1z0 <e1z0@icloud.com>
+ *
+ * This file is part of Benchy.
+ *
+ * Benchy is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Benchy is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with Benchy. If not, see <https://www.gnu.org/licenses/>.
+ */
+package ui
+
+import (
+	"fmt"
+
+	"github.com/e1z0/Benchy/internal/benchmarks"
+)
+
+// MatrixTable lays matrices out as table rows for the detail view, one
+// block per matrix, with a title row before each block.
+func MatrixTable(ms []*benchmarks.Matrix) (headers []string, rows [][]string) {
+	cols := 0
+	for _, m := range ms {
+		cols = max(cols, len(m.Cols))
+	}
+	headers = make([]string, cols+1)
+	for _, m := range ms {
+		head := make([]string, cols+1)
+		head[0] = fmt.Sprintf("%s (%s), %s ↓ / %s →", m.Title, m.Unit, m.RowLabel, m.ColLabel)
+		copy(head[1:], m.Cols)
+		rows = append(rows, head)
+		for i, label := range m.Rows {
+			row := make([]string, cols+1)
+			row[0] = label
+			for j, v := range m.Values[i] {
+				row[j+1] = fmt.Sprintf("%.1f", v)
+			}
+			rows = append(rows, row)
+		}
+	}
+	return headers, rows
+}
@@ -84,7 +84,10 @@ func RunSuiteDialog(parent *qt.QWidget, mode string, threads int, dur time.Durat
 	})
 	for i, t := range tests {
 		if canceled {
-			break
+			// keep the remaining tests in the results so that scores
+			// are marked incomplete rather than computed over a subset
+			res.Results = append(res.Results, benchmarks.Skip(t.Name, threads, "suite canceled"))
+			continue
 		}
 		cur.SetText(fmt.Sprintf("Test %d/%d — %s", i+1, len(tests), t.Name))
 		log.AppendPlainText(fmt.Sprintf("> %s", t.Name))
@@ -92,9 +95,9 @@ func RunSuiteDialog(parent *qt.QWidget, mode string, threads int, dur time.Durat
 		ctx, cancel := context.WithCancel(context.Background())
 		done := make(chan struct{})
 
+		var r benchmarks.Result
 		go func() {
-			r := t.Run(ctx, dur, threads)
-			res.Results = append(res.Results, r)
+			r = t.Run(ctx, dur, threads)
 			close(done)
 		}()
 
@@ -126,6 +129,11 @@ func RunSuiteDialog(parent *qt.QWidget, mode string, threads int, dur time.Durat
 		}
 		tick.Stop()
 		cancel()
+		r.Settle(canceled)
+		res.Results = append(res.Results, r)
+		if r.Status != benchmarks.StatusPassed {
+			log.AppendPlainText(fmt.Sprintf("  %s: %s", r.Status, r.Err))
+		}
 		qt.QCoreApplication_ProcessEvents()
 	}
 
@@ -24,12 +24,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"math"
 	"os"
 	"path/filepath"
+	"slices"
 	"time"
 
 	"github.com/e1z0/Benchy/internal/benchmarks"
+	"github.com/e1z0/Benchy/internal/power"
 	"github.com/e1z0/Benchy/internal/scoring"
 	"github.com/e1z0/Benchy/internal/sysinfo"
 	"github.com/e1z0/Benchy/internal/ui"
@@ -41,11 +42,12 @@ type tabWidgets struct {
 	table    *qt.QTableWidget
 	overall  *qt.QLabel
 	chart    *ui.BarChart
-	tileCPU  *ui.Tile
-	tileMem  *ui.Tile
-	tileStor *ui.Tile
-	tileImg  *ui.Tile
+	hist     *ui.HistogramChart
+	curve    *ui.LineChart
+	detail   *qt.QTableWidget
+	tiles    map[string]*ui.Tile // per scoring section
 	lastJSON []byte
+	results  []benchmarks.Result
 }
 
 func newTab(title string, parent *qt.QTabWidget) *tabWidgets {
@@ -53,14 +55,12 @@ func newTab(title string, parent *qt.QTabWidget) *tabWidgets {
 	v := qt.NewQVBoxLayout(w)
 
 	tiles := qt.NewQHBoxLayout2()
-	tileCPU := ui.NewTile("CPU")
-	tileMem := ui.NewTile("Memory")
-	tileStor := ui.NewTile("Storage")
-	tileImg := ui.NewTile("Image")
-	tiles.AddWidget(tileCPU.Box.QWidget)
-	tiles.AddWidget(tileMem.Box.QWidget)
-	tiles.AddWidget(tileStor.Box.QWidget)
-	tiles.AddWidget(tileImg.Box.QWidget)
+	sectionTiles := map[string]*ui.Tile{}
+	for _, sec := range scoring.Sections {
+		tile := ui.NewTile(sec.Name)
+		sectionTiles[sec.Name] = tile
+		tiles.AddWidget(tile.Box.QWidget)
+	}
 
 	overall := qt.NewQLabel5("Overall: —", nil)
 	f := overall.Font()
@@ -68,21 +68,66 @@ func newTab(title string, parent *qt.QTabWidget) *tabWidgets {
 	f.SetBold(true)
 	overall.SetFont(f)
 
-	tbl := qt.NewQTableWidget4(0, 6, nil)
-	tbl.SetHorizontalHeaderLabels([]string{"Test", "Threads", "Duration (s)", "Throughput", "Score", "Notes"})
+	tbl := qt.NewQTableWidget4(0, 12, nil)
+	tbl.SetHorizontalHeaderLabels([]string{"Test", "Status", "Threads", "Duration (s)", "Throughput", "Score", "Efficiency", "Eff. score",
+		"p50", "p99", "p99.9", "Notes"})
 	tbl.HorizontalHeader().SetStretchLastSection(true)
 
 	chart := ui.NewBarChart(nil)
+	hist := ui.NewHistogramChart(nil)
+	curve := ui.NewLineChart(nil)
+	curve.SetVisible(false)
+	detail := qt.NewQTableWidget4(0, 0, nil)
+	detail.SetVisible(false)
+	charts := qt.NewQHBoxLayout2()
+	charts.AddWidget(chart.QWidget)
+	charts.AddWidget(hist.QWidget)
+	charts.AddWidget(curve.QWidget)
+	charts.AddWidget(detail.QWidget)
 
 	v.AddLayout(tiles.QLayout)
 	v.AddWidget(overall.QWidget)
 	v.AddWidget(tbl.QWidget)
-	v.AddWidget(chart.QWidget)
+	v.AddLayout(charts.QLayout)
 
 	parent.AddTab(w, title)
-	return &tabWidgets{
-		table: tbl, overall: overall, chart: chart,
-		tileCPU: tileCPU, tileMem: tileMem, tileStor: tileStor, tileImg: tileImg,
+	t := &tabWidgets{
+		table: tbl, overall: overall, chart: chart, hist: hist, curve: curve, detail: detail,
+		tiles: sectionTiles,
+	}
+	// selecting a row shows that test's curve and its points if it has
+	// one, otherwise its latency histogram
+	tbl.OnCurrentCellChanged(func(row, col, prevRow, prevCol int) {
+		if row < 0 || row >= len(t.results) {
+			return
+		}
+		r := t.results[row]
+		t.curve.SetVisible(r.Series != nil)
+		t.detail.SetVisible(r.Series != nil || len(r.Matrices) > 0)
+		t.hist.SetVisible(r.Series == nil && len(r.Matrices) == 0)
+		t.curve.SetSeries(r.Name, r.Series)
+		t.hist.SetHistogram(r.Name, r.Latency)
+		switch {
+		case r.Series != nil:
+			headers, rows := ui.SeriesTable(r.Series)
+			fillDetail(t.detail, headers, rows)
+		case len(r.Matrices) > 0:
+			headers, rows := ui.MatrixTable(r.Matrices)
+			fillDetail(t.detail, headers, rows)
+		}
+	})
+	return t
+}
+
+func fillDetail(tbl *qt.QTableWidget, headers []string, rows [][]string) {
+	tbl.SetRowCount(0)
+	tbl.SetColumnCount(len(headers))
+	tbl.SetHorizontalHeaderLabels(headers)
+	for i, row := range rows {
+		tbl.InsertRow(i)
+		for j, cell := range row {
+			tbl.SetItem(i, j, qt.NewQTableWidgetItem2(cell))
+		}
 	}
 }
 
@@ -100,84 +145,535 @@ func main() {
 	info := qt.NewQPlainTextEdit(nil)
 	info.SetReadOnly(true)
 	info.SetPlainText(si.String())
+	meter, err := power.Open(power.DefaultRoot)
+	if err != nil {
+		info.AppendPlainText("Energy: " + err.Error() + "; efficiency is not reported")
+	} else {
+		info.AppendPlainText("Energy: " + meter.String())
+	}
 
 	durLbl := qt.NewQLabel3("Duration (s):")
 	dur := qt.NewQSpinBox(nil)
 	dur.SetRange(1, 60)
 	dur.SetValue(5)
+	pathLbl := qt.NewQLabel3("Disk path:")
+	diskPath := qt.NewQLineEdit3(os.TempDir())
+	matLbl := qt.NewQLabel3("Matrix size:")
+	matBox := qt.NewQComboBox(nil)
+	for i, n := range benchmarks.MatMulSizes {
+		matBox.AddItem(fmt.Sprintf("%d×%d", n, n))
+		if n == benchmarks.DefaultMatMulSize {
+			matBox.SetCurrentIndex(i)
+		}
+	}
+	corpusLbl := qt.NewQLabel3("Corpus:")
+	corpusBox := qt.NewQComboBox(nil)
+	for _, p := range benchmarks.CorpusProfiles {
+		corpusBox.AddItem(p.Name)
+	}
+	bufLbl := qt.NewQLabel3("Crypto buffer:")
+	bufBox := qt.NewQComboBox(nil)
+	for i, n := range benchmarks.CryptoBufSizes {
+		bufBox.AddItem(benchmarks.BufLabel(n))
+		if n == benchmarks.DefaultCryptoBuf {
+			bufBox.SetCurrentIndex(i)
+		}
+	}
+	jsonLbl := qt.NewQLabel3("JSON records:")
+	jsonRecs := qt.NewQSpinBox(nil)
+	jsonRecs.SetRange(10, 100000)
+	jsonRecs.SetValue(benchmarks.DefaultJSONShape.Records)
+	depthLbl := qt.NewQLabel3("Depth:")
+	jsonDepth := qt.NewQSpinBox(nil)
+	jsonDepth.SetRange(1, 32)
+	jsonDepth.SetValue(benchmarks.DefaultJSONShape.Depth)
+	jsonKind := qt.NewQComboBox(nil)
+	jsonKind.AddItem("number-heavy")
+	jsonKind.AddItem("string-heavy")
+	resLbl := qt.NewQLabel3("Image size:")
+	resBox := qt.NewQComboBox(nil)
+	for _, r := range benchmarks.ImageResolutions {
+		resBox.AddItem(r.Name)
+	}
+	resBox.SetCurrentIndex(1)
+	heapLbl := qt.NewQLabel3("Live heap (MB):")
+	liveHeap := qt.NewQSpinBox(nil)
+	liveHeap.SetRange(16, 16384)
+	liveHeap.SetValue(benchmarks.DefaultLiveHeapMB)
+	refLbl := qt.NewQLabel3("Reference:")
+	refBox := qt.NewQComboBox(nil)
+	for _, n := range scoring.Builtin() {
+		refBox.AddItem(n)
+	}
+	refBox.SetCurrentIndex(max(0, refBox.FindText(scoring.DefaultRefSet)))
+	refLoad := qt.NewQPushButton3("Load…")
+	weightLbl := qt.NewQLabel3("Weights:")
+	weightBox := qt.NewQComboBox(nil)
+	for _, w := range scoring.WeightPresets {
+		weightBox.AddItem(w.Name)
+	}
+	weightBox.SetCurrentIndex(max(0, weightBox.FindText(scoring.DefaultWeights)))
+	weightLoad := qt.NewQPushButton3("Load…")
+	topo, topoErr := sysinfo.ReadTopology(sysinfo.DefaultSysfs)
+	affLbl := qt.NewQLabel3("Affinity:")
+	affBox := qt.NewQComboBox(nil)
+	affBox.AddItem(affinityOS)
+	if topoErr == nil {
+		affBox.AddItem(affinityPhysical)
+		for _, n := range topo.Nodes() {
+			affBox.AddItem(fmt.Sprintf("NUMA node %d", n))
+		}
+		affBox.AddItem(affinityCPUs)
+	}
+	affCPUs := qt.NewQLineEdit(nil)
+	affCPUs.SetPlaceholderText("e.g. 0-3,8")
+	affCPUs.SetEnabled(false)
+	affBox.OnCurrentIndexChanged(func(int) {
+		affCPUs.SetEnabled(affBox.CurrentText() == affinityCPUs)
+	})
+	classes := topo.Classes()
+	perClass := qt.NewQCheckBox3("Single-Core per core class")
+	perClass.SetEnabled(len(classes) > 1)
+	if len(classes) < 2 {
+		perClass.SetToolTip("all cores are of one kind")
+	}
+	numa := qt.NewQCheckBox3("NUMA memory matrix")
+	sweep := qt.NewQCheckBox3("Compression level sweep")
+	sizeSweep := qt.NewQCheckBox3("Crypto size sweep")
 	run := qt.NewQPushButton3("Run Both")
 	exp1 := qt.NewQPushButton3("Export Single-Core JSON")
 	exp1.SetEnabled(false)
 	expm := qt.NewQPushButton3("Export Multi-Core JSON")
 	expm.SetEnabled(false)
+	expc := qt.NewQPushButton3("Export Core-Class JSON")
+	expc.SetEnabled(false)
 
 	tabs := qt.NewQTabWidget(nil)
 	single := newTab("Single-Core", tabs)
 	multi := newTab("Multi-Core", tabs)
+	// one Single-Core tab per hybrid core class, added on first use
+	classTabs := map[string]*tabWidgets{}
 
 	opts := qt.NewQHBoxLayout(nil)
 	opts.AddWidget(durLbl.QWidget)
 	opts.AddWidget(dur.QWidget)
 	opts.AddSpacing(8)
+	opts.AddWidget(pathLbl.QWidget)
+	opts.AddWidget(diskPath.QWidget)
+	opts.AddSpacing(8)
+	opts.AddWidget(matLbl.QWidget)
+	opts.AddWidget(matBox.QWidget)
+	opts.AddSpacing(8)
+	opts.AddWidget(corpusLbl.QWidget)
+	opts.AddWidget(corpusBox.QWidget)
+	opts.AddSpacing(8)
+	opts.AddWidget(bufLbl.QWidget)
+	opts.AddWidget(bufBox.QWidget)
+	opts.AddSpacing(8)
+	opts.AddWidget(sweep.QWidget)
+	opts.AddWidget(sizeSweep.QWidget)
+	opts.AddSpacing(8)
 	opts.AddWidget(run.QWidget)
 	opts.AddStretch()
 	opts.AddWidget(exp1.QWidget)
 	opts.AddWidget(expm.QWidget)
+	opts.AddWidget(expc.QWidget)
+
+	opts2 := qt.NewQHBoxLayout(nil)
+	opts2.AddWidget(jsonLbl.QWidget)
+	opts2.AddWidget(jsonRecs.QWidget)
+	opts2.AddWidget(depthLbl.QWidget)
+	opts2.AddWidget(jsonDepth.QWidget)
+	opts2.AddWidget(jsonKind.QWidget)
+	opts2.AddSpacing(8)
+	opts2.AddWidget(resLbl.QWidget)
+	opts2.AddWidget(resBox.QWidget)
+	opts2.AddSpacing(8)
+	opts2.AddWidget(heapLbl.QWidget)
+	opts2.AddWidget(liveHeap.QWidget)
+	opts2.AddSpacing(8)
+	opts2.AddWidget(refLbl.QWidget)
+	opts2.AddWidget(refBox.QWidget)
+	opts2.AddWidget(refLoad.QWidget)
+	opts2.AddSpacing(8)
+	opts2.AddWidget(weightLbl.QWidget)
+	opts2.AddWidget(weightBox.QWidget)
+	opts2.AddWidget(weightLoad.QWidget)
+	opts2.AddStretch()
 
 	root.AddWidget(info.QWidget)
 	root.AddLayout(opts.QLayout)
+	root.AddLayout(opts2.QLayout)
+	opts3 := qt.NewQHBoxLayout(nil)
+	opts3.AddWidget(affLbl.QWidget)
+	opts3.AddWidget(affBox.QWidget)
+	opts3.AddWidget(affCPUs.QWidget)
+	opts3.AddSpacing(8)
+	opts3.AddWidget(perClass.QWidget)
+	opts3.AddSpacing(8)
+	opts3.AddWidget(numa.QWidget)
+	opts3.AddStretch()
+	root.AddLayout(opts3.QLayout)
 	root.AddWidget(tabs.QWidget)
 	win.SetCentralWidget(central)
 
+	// benchDir, matN and corpus are read from the UI before each run; the
+	// tests execute off the Qt thread and must not touch widgets themselves.
+	benchDir := os.TempDir()
+	matN := benchmarks.DefaultMatMulSize
+	corpus := benchmarks.CorpusProfiles[0].Name
+	cryptoBuf := benchmarks.DefaultCryptoBuf
+	jsonShape := benchmarks.DefaultJSONShape
+	liveMB := benchmarks.DefaultLiveHeapMB
+	imgRes := benchmarks.LookupImageRes("1080p")
+
+	// refSets holds every reference set offered in refBox; loaded files
+	// are added under their set name and replace a set of the same name.
+	refSets := map[string]*scoring.RefSet{}
+	ref := scoring.Default()
+	refSets[ref.Name] = ref
+	// weightSets likewise for weightBox, seeded with the presets
+	weightSets := map[string]scoring.Weights{}
+	for _, w := range scoring.WeightPresets {
+		weightSets[w.Name] = w
+	}
+	weights := scoring.LookupWeights(scoring.DefaultWeights)
+
+	streamN := benchmarks.StreamArrayLen(si.LLCBytes, si.TotalRAMBytes)
+
 	// Ordered test list
 	tests := []ui.TestSpec{
 		{Name: "CPU SHA-256", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
 			return benchmarks.RunCPUSHA256(ctx, d, th)
 		}},
 		{Name: "AES-CTR", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
-			return benchmarks.RunCPUAES(ctx, d, th, 32)
+			return benchmarks.RunCPUAES(ctx, d, th, 32, 0)
+		}},
+		{Name: "SHA-512", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunCPUHash(ctx, d, th, benchmarks.SHA512, cryptoBuf)
+		}},
+		{Name: "BLAKE2b", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunCPUHash(ctx, d, th, benchmarks.BLAKE2b, cryptoBuf)
+		}},
+		{Name: "AES-GCM", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunCPUAEAD(ctx, d, th, benchmarks.AESGCM, cryptoBuf)
+		}},
+		{Name: "ChaCha20-Poly1305", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunCPUAEAD(ctx, d, th, benchmarks.ChaCha20Poly1305, cryptoBuf)
+		}},
+		{Name: "ECDSA P-256 Sign", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunCPUSign(ctx, d, th, benchmarks.ECDSAP256, false)
+		}},
+		{Name: "ECDSA P-256 Verify", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunCPUSign(ctx, d, th, benchmarks.ECDSAP256, true)
+		}},
+		{Name: "Ed25519 Sign", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunCPUSign(ctx, d, th, benchmarks.Ed25519, false)
+		}},
+		{Name: "Ed25519 Verify", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunCPUSign(ctx, d, th, benchmarks.Ed25519, true)
 		}},
 		{Name: "Zstd Compress", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
-			return benchmarks.RunCPUZstd(ctx, d, th, 3)
+			return benchmarks.RunCPUZstd(ctx, d, th, 3, corpus)
 		}},
 		{Name: "Gzip Compress", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
-			return benchmarks.RunCPUGzip(ctx, d, th, -1)
+			return benchmarks.RunCPUGzip(ctx, d, th, -1, corpus)
+		}},
+		{Name: "Zstd Decompress", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunCPUZstdDecompress(ctx, d, th, 3, corpus)
+		}},
+		{Name: "Gzip Decompress", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunCPUGzipDecompress(ctx, d, th, -1, corpus)
 		}},
 		{Name: "JSON Parse", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
-			return benchmarks.RunCPUJSONParse(ctx, d, th)
+			return benchmarks.RunCPUJSONParse(ctx, d, th, jsonShape)
+		}},
+		{Name: "JSON Parse Map", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunCPUJSONParseMap(ctx, d, th, jsonShape)
+		}},
+		{Name: "JSON Encode", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunCPUJSONEncode(ctx, d, th, jsonShape)
+		}},
+		{Name: "Sort", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunCPUSort(ctx, d, th)
+		}},
+		{Name: "Regex", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunCPURegex(ctx, d, th)
+		}},
+		{Name: "Map ops", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunCPUMap(ctx, d, th)
+		}},
+		{Name: "Dijkstra", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunCPUDijkstra(ctx, d, th)
 		}},
 		{Name: "MatMul", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
-			return benchmarks.RunMatMul(ctx, d, th, 256)
+			return benchmarks.RunMatMul(ctx, d, th, matN, benchmarks.Float64)
+		}},
+		{Name: "MatMul FP32", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunMatMul(ctx, d, th, matN, benchmarks.Float32)
+		}},
+		{Name: "FFT", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunCPUFFT(ctx, d, th)
+		}},
+		{Name: "N-body", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunCPUNBody(ctx, d, th)
+		}},
+		{Name: "Mandelbrot", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunCPUMandelbrot(ctx, d, th)
 		}},
 		{Name: "Memory copy", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
 			return benchmarks.RunMemCopy(ctx, d, th)
 		}},
+		{Name: "Memory latency", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunMemLatency(ctx, d, benchmarks.MaxWorkingSet(si.TotalRAMBytes))
+		}},
+		{Name: "STREAM Copy", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunStream(ctx, d, th, benchmarks.StreamCopy, streamN)
+		}},
+		{Name: "STREAM Scale", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunStream(ctx, d, th, benchmarks.StreamScale, streamN)
+		}},
+		{Name: "STREAM Add", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunStream(ctx, d, th, benchmarks.StreamAdd, streamN)
+		}},
+		{Name: "STREAM Triad", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunStream(ctx, d, th, benchmarks.StreamTriad, streamN)
+		}},
 		{Name: "Gaussian Blur 1080p", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
 			return benchmarks.RunImageBlur(ctx, d, th)
 		}},
+		{Name: "PNG Encode", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunImageCodec(ctx, d, th, imgRes, benchmarks.PNG, false)
+		}},
+		{Name: "PNG Decode", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunImageCodec(ctx, d, th, imgRes, benchmarks.PNG, true)
+		}},
+		{Name: "JPEG Encode", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunImageCodec(ctx, d, th, imgRes, benchmarks.JPEG, false)
+		}},
+		{Name: "JPEG Decode", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunImageCodec(ctx, d, th, imgRes, benchmarks.JPEG, true)
+		}},
+		{Name: "Resize Bilinear", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunImageResize(ctx, d, th, imgRes, benchmarks.Bilinear)
+		}},
+		{Name: "Resize Lanczos", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunImageResize(ctx, d, th, imgRes, benchmarks.Lanczos3)
+		}},
+		{Name: "YCbCr Convert", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunImageYCbCr(ctx, d, th, imgRes)
+		}},
+		{Name: "Sobel", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunImageSobel(ctx, d, th, imgRes)
+		}},
+		{Name: "Alloc rate", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunAllocRate(ctx, d, th)
+		}},
+		{Name: "GC pressure", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunGCPressure(ctx, d, th, liveMB)
+		}},
+		{Name: "Chan ping-pong", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunChanPingPong(ctx, d, th)
+		}},
+		{Name: "Mutex contention", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunMutexContention(ctx, d, th)
+		}},
 		{Name: "Disk seq R/W", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
-			return benchmarks.RunDiskSeq(ctx, d, filepath.Join(os.TempDir(), "benchyqt.seq"))
+			return benchmarks.RunDiskSeq(ctx, d, filepath.Join(benchDir, "benchyqt.seq"))
+		}},
+		{Name: "Disk metadata", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunDiskMeta(ctx, d, th, benchDir)
+		}},
+	}
+	// optional, unscored: appended when the sweep box is checked
+	sweepTests := []ui.TestSpec{
+		{Name: "Zstd Level Sweep", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunCPUZstdSweep(ctx, d, th, corpus)
+		}},
+		{Name: "Gzip Level Sweep", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunCPUGzipSweep(ctx, d, th, corpus)
+		}},
+	}
+	// optional, unscored: local vs remote memory per NUMA node pair; with
+	// no topology it reports itself skipped
+	var numaNodes []benchmarks.NUMANode
+	if topoErr == nil {
+		for _, n := range topo.Nodes() {
+			numaNodes = append(numaNodes, benchmarks.NUMANode{ID: n, CPUs: topo.NodeCPUs(n)})
+		}
+	}
+	numaTests := []ui.TestSpec{
+		{Name: "NUMA memory", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunMemNUMA(ctx, d, th, numaNodes, benchmarks.MaxWorkingSet(si.TotalRAMBytes))
+		}},
+	}
+	// optional, unscored: throughput per message size, like openssl speed
+	sizeSweepTests := []ui.TestSpec{
+		{Name: "SHA-256 Size Sweep", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunHashSizeSweep(ctx, d, th, benchmarks.SHA256)
+		}},
+		{Name: "SHA-512 Size Sweep", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunHashSizeSweep(ctx, d, th, benchmarks.SHA512)
+		}},
+		{Name: "BLAKE2b Size Sweep", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunHashSizeSweep(ctx, d, th, benchmarks.BLAKE2b)
+		}},
+		{Name: "AES-CTR Size Sweep", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunAESSizeSweep(ctx, d, th)
+		}},
+		{Name: "AES-GCM Size Sweep", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunAEADSizeSweep(ctx, d, th, benchmarks.AESGCM)
+		}},
+		{Name: "ChaCha20-Poly1305 Size Sweep", Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunAEADSizeSweep(ctx, d, th, benchmarks.ChaCha20Poly1305)
 		}},
 	}
 
 	run.OnClicked(func() {
+		place, err := placementFor(affBox.CurrentText(), affCPUs.Text(), topo)
+		if err != nil {
+			qt.QMessageBox_Warning(win.QWidget, "Affinity", err.Error())
+			return
+		}
 		run.SetEnabled(false)
 		exp1.SetEnabled(false)
 		expm.SetEnabled(false)
+		expc.SetEnabled(false)
 
 		d := time.Duration(dur.Value()) * time.Second
+		if p := diskPath.Text(); p != "" {
+			benchDir = p
+		}
+		matN = benchmarks.MatMulSizes[max(0, matBox.CurrentIndex())]
+		corpus = corpusBox.CurrentText()
+		cryptoBuf = benchmarks.CryptoBufSizes[max(0, bufBox.CurrentIndex())]
+		liveMB = liveHeap.Value()
+		imgRes = benchmarks.LookupImageRes(resBox.CurrentText())
+		jsonShape = benchmarks.JSONShape{Records: jsonRecs.Value(), Depth: jsonDepth.Value(), StringHeavy: jsonKind.CurrentIndex() == 1}
+		suite := tests
+		if sweep.IsChecked() {
+			suite = append(append([]ui.TestSpec{}, suite...), sweepTests...)
+		}
+		if sizeSweep.IsChecked() {
+			suite = append(append([]ui.TestSpec{}, suite...), sizeSweepTests...)
+		}
+		if numa.IsChecked() {
+			suite = append(append([]ui.TestSpec{}, suite...), numaTests...)
+		}
+		base := suite
+		suite = metered(meter, placed(place, suite))
 
 		// Single-Core first
-		sres := ui.RunSuiteDialog(win.QWidget, "Single-Core", 1, d, tests)
-		populateTab(single, sres.Results)
+		sres := ui.RunSuiteDialog(win.QWidget, "Single-Core", 1, d, suite)
+		populateTab(single, sres.Results, ref, weights)
+
+		// then once per core class, pinned to one CPU of that class
+		if perClass.IsChecked() && perClass.IsEnabled() {
+			for _, cl := range classes {
+				p := benchmarks.Placement{Label: cl.Name, CPUs: []int{cl.Representative()}}
+				cres := ui.RunSuiteDialog(win.QWidget, "Single-Core "+cl.Name, 1, d, metered(meter, placed(p, base)))
+				t := classTabs[cl.Name]
+				if t == nil {
+					t = newTab("Single-Core "+cl.Name, tabs)
+					classTabs[cl.Name] = t
+				}
+				populateTab(t, cres.Results, ref, weights)
+			}
+		}
 
 		// Multi-Core second
-		mres := ui.RunSuiteDialog(win.QWidget, "Multi-Core", sysinfo.Collect().LogicalCPUs, d, tests)
-		populateTab(multi, mres.Results)
+		// pinned runs use one worker per allowed CPU
+		threads := sysinfo.Collect().LogicalCPUs
+		if len(place.CPUs) > 0 {
+			threads = len(place.CPUs)
+		}
+		mres := ui.RunSuiteDialog(win.QWidget, "Multi-Core", threads, d, suite)
+		populateTab(multi, mres.Results, ref, weights)
 
 		run.SetEnabled(true)
 		exp1.SetEnabled(true)
 		expm.SetEnabled(true)
+		expc.SetEnabled(len(classTabs) > 0)
+	})
+
+	// switching reference rescores what is already on screen
+	rescore := func() {
+		if single.results != nil {
+			populateTab(single, single.results, ref, weights)
+		}
+		if multi.results != nil {
+			populateTab(multi, multi.results, ref, weights)
+		}
+		for _, t := range classTabs {
+			populateTab(t, t.results, ref, weights)
+		}
+	}
+	refBox.OnCurrentIndexChanged(func(int) {
+		name := refBox.CurrentText()
+		set, ok := refSets[name]
+		if !ok {
+			var err error
+			if set, err = scoring.LoadBuiltin(name); err != nil {
+				info.AppendPlainText("Reference: " + err.Error())
+				return
+			}
+			refSets[name] = set
+		}
+		ref = set
+		rescore()
+	})
+	refLoad.OnClicked(func() {
+		fn := qt.QFileDialog_GetOpenFileName4(win.QWidget, "Load reference set or exported results", userHome(), "JSON (*.json)")
+		if fn == "" {
+			return
+		}
+		set, err := scoring.LoadFile(fn)
+		if err != nil {
+			qt.QMessageBox_Warning(win.QWidget, "Reference", err.Error())
+			return
+		}
+		refSets[set.Name] = set
+		info.AppendPlainText(fmt.Sprintf("Reference %s: %s", set.Name, set.Description))
+		i := refBox.FindText(set.Name)
+		if i < 0 {
+			refBox.AddItem(set.Name)
+			i = refBox.Count() - 1
+		}
+		if i == refBox.CurrentIndex() {
+			// re-selecting the current entry emits no signal
+			ref = set
+			rescore()
+			return
+		}
+		refBox.SetCurrentIndex(i)
+	})
+
+	weightBox.OnCurrentIndexChanged(func(int) {
+		weights = weightSets[weightBox.CurrentText()]
+		rescore()
+	})
+	weightLoad.OnClicked(func() {
+		fn := qt.QFileDialog_GetOpenFileName4(win.QWidget, "Load score weights", userHome(), "JSON (*.json)")
+		if fn == "" {
+			return
+		}
+		w, err := scoring.LoadWeights(fn)
+		if err != nil {
+			qt.QMessageBox_Warning(win.QWidget, "Weights", err.Error())
+			return
+		}
+		weightSets[w.Name] = w
+		i := weightBox.FindText(w.Name)
+		if i < 0 {
+			weightBox.AddItem(w.Name)
+			i = weightBox.Count() - 1
+		}
+		if i == weightBox.CurrentIndex() {
+			weights = w
+			rescore()
+			return
+		}
+		weightBox.SetCurrentIndex(i)
 	})
 
 	exp1.OnClicked(func() {
@@ -197,6 +693,30 @@ func main() {
 		info.AppendPlainText("Saved: " + fn)
 	})
 
+	expc.OnClicked(func() {
+		// one file with a Single-Core export per class, fastest first
+		type classRun struct {
+			Class string          `json:"class"`
+			CPU   int             `json:"cpu"`
+			Run   json.RawMessage `json:"run"`
+		}
+		var runs []classRun
+		for _, cl := range classes {
+			if t := classTabs[cl.Name]; t != nil && len(t.lastJSON) > 0 {
+				runs = append(runs, classRun{Class: cl.Name, CPU: cl.Representative(), Run: t.lastJSON})
+			}
+		}
+		if len(runs) == 0 {
+			return
+		}
+		b, _ := json.MarshalIndent(struct {
+			Classes []classRun `json:"classes"`
+		}{runs}, "", "  ")
+		fn := filepath.Join(userHome(), fmt.Sprintf("benchyqt-classes-%d.json", time.Now().Unix()))
+		_ = os.WriteFile(fn, b, 0644)
+		info.AppendPlainText("Saved: " + fn)
+	})
+
 	win.Resize(1200, 760)
 	win.Show()
 	qt.QApplication_Exec()
@@ -210,22 +730,183 @@ func shortName(s string) string {
 		return "Blur1080p"
 	case "Memory copy":
 		return "MemCopy"
+	case "Memory latency":
+		return "MemLat"
+	case "NUMA memory":
+		return "NUMA"
+	case "STREAM Copy":
+		return "Copy"
+	case "STREAM Scale":
+		return "Scale"
+	case "STREAM Add":
+		return "Add"
+	case "STREAM Triad":
+		return "Triad"
 	case "Disk seq R/W":
 		return "DiskSeq"
+	case "Disk metadata":
+		return "DiskMeta"
 	case "Zstd Compress":
 		return "Zstd"
 	case "Gzip Compress":
 		return "Gzip"
+	case "Zstd Decompress":
+		return "UnZstd"
+	case "Gzip Decompress":
+		return "Gunzip"
+	case "Zstd Level Sweep":
+		return "ZstdSweep"
+	case "Gzip Level Sweep":
+		return "GzipSweep"
+	case "SHA-256 Size Sweep":
+		return "SHA256Sz"
+	case "SHA-512 Size Sweep":
+		return "SHA512Sz"
+	case "BLAKE2b Size Sweep":
+		return "BLAKE2bSz"
+	case "AES-CTR Size Sweep":
+		return "AESSz"
+	case "AES-GCM Size Sweep":
+		return "GCMSz"
+	case "ChaCha20-Poly1305 Size Sweep":
+		return "ChaChaSz"
 	case "JSON Parse":
 		return "JSON"
+	case "JSON Parse Map":
+		return "JSONMap"
+	case "JSON Encode":
+		return "JSONEnc"
 	case "AES-CTR":
 		return "AES"
+	case "SHA-512":
+		return "SHA512"
+	case "BLAKE2b":
+		return "BLAKE2b"
+	case "AES-GCM":
+		return "GCM"
+	case "ChaCha20-Poly1305":
+		return "ChaCha"
+	case "ECDSA P-256 Sign":
+		return "P256Sign"
+	case "ECDSA P-256 Verify":
+		return "P256Vrfy"
+	case "Ed25519 Sign":
+		return "EdSign"
+	case "Ed25519 Verify":
+		return "EdVrfy"
+	case "Map ops":
+		return "Map"
 	case "MatMul":
 		return "MatMul"
+	case "MatMul FP32":
+		return "MatMul32"
+	case "N-body":
+		return "NBody"
+	case "Mandelbrot":
+		return "Mandel"
+	case "PNG Encode":
+		return "PNGEnc"
+	case "PNG Decode":
+		return "PNGDec"
+	case "JPEG Encode":
+		return "JPGEnc"
+	case "JPEG Decode":
+		return "JPGDec"
+	case "Resize Bilinear":
+		return "Bilinear"
+	case "Resize Lanczos":
+		return "Lanczos"
+	case "YCbCr Convert":
+		return "YCbCr"
+	case "Alloc rate":
+		return "Alloc"
+	case "GC pressure":
+		return "GC"
+	case "Chan ping-pong":
+		return "PingPong"
+	case "Mutex contention":
+		return "Mutex"
 	}
 	return s
 }
 
+// Affinity choices besides the per-node ones.
+const (
+	affinityOS       = "OS scheduler"
+	affinityPhysical = "Physical cores"
+	affinityCPUs     = "CPUs…"
+)
+
+// placementFor resolves the affinity choice into the CPUs to pin to.
+func placementFor(choice, cpuList string, topo sysinfo.Topology) (benchmarks.Placement, error) {
+	switch {
+	case choice == affinityOS:
+		return benchmarks.Placement{Label: "os"}, nil
+	case choice == affinityPhysical:
+		return benchmarks.Placement{Label: "physical cores", CPUs: topo.PhysicalCores()}, nil
+	case choice == affinityCPUs:
+		cpus, err := sysinfo.ParseCPUList(cpuList)
+		if err != nil {
+			return benchmarks.Placement{}, err
+		}
+		if len(cpus) == 0 {
+			return benchmarks.Placement{}, fmt.Errorf("no CPUs given")
+		}
+		for _, c := range cpus {
+			if !slices.ContainsFunc(topo.CPUs, func(o sysinfo.CPU) bool { return o.ID == c }) {
+				return benchmarks.Placement{}, fmt.Errorf("CPU %d is not online", c)
+			}
+		}
+		return benchmarks.Placement{Label: "cpus", CPUs: cpus}, nil
+	}
+	var n int
+	if _, err := fmt.Sscanf(choice, "NUMA node %d", &n); err != nil {
+		return benchmarks.Placement{}, fmt.Errorf("unknown affinity %q", choice)
+	}
+	return benchmarks.Placement{Label: fmt.Sprintf("node %d", n), CPUs: topo.NodeCPUs(n)}, nil
+}
+
+// placed wraps each test to run with its workers pinned per p.
+func placed(p benchmarks.Placement, specs []ui.TestSpec) []ui.TestSpec {
+	out := make([]ui.TestSpec, len(specs))
+	for i, spec := range specs {
+		run := spec.Run
+		out[i] = ui.TestSpec{Name: spec.Name, Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			return benchmarks.RunPlaced(ctx, p, func(ctx context.Context) benchmarks.Result {
+				return run(ctx, d, th)
+			})
+		}}
+	}
+	return out
+}
+
+// metered wraps each test to record the package energy it used. With no
+// meter the tests are returned as they are.
+func metered(m *power.Meter, specs []ui.TestSpec) []ui.TestSpec {
+	if m == nil {
+		return specs
+	}
+	out := make([]ui.TestSpec, len(specs))
+	for i, spec := range specs {
+		run := spec.Run
+		out[i] = ui.TestSpec{Name: spec.Name, Run: func(ctx context.Context, d time.Duration, th int) benchmarks.Result {
+			before, err := m.Read()
+			r := run(ctx, d, th)
+			if err != nil {
+				return r
+			}
+			after, err := m.Read()
+			if err != nil {
+				return r
+			}
+			e := m.Between(before, after)
+			r.SetEnergy(e.Joules, e.Elapsed, m.String())
+			return r
+		}}
+	}
+	return out
+}
+
 func userHome() string {
 	h, err := os.UserHomeDir()
 	if err != nil {
@@ -234,82 +915,121 @@ func userHome() string {
 	return h
 }
 
-func geo(vals []float64) float64 {
-	if len(vals) == 0 {
-		return 0
+func placementString(p *benchmarks.Placement) string {
+	if len(p.CPUs) == 0 {
+		return "placed by the OS scheduler"
 	}
-	prod := 1.0
-	n := 0
-	for _, v := range vals {
-		if v > 0 {
-			prod *= v
-			n++
-		}
+	s := fmt.Sprintf("pinned to %s: CPUs %s, used %s", p.Label, sysinfo.FormatCPUList(p.CPUs), sysinfo.FormatCPUList(p.Used))
+	if p.Err != "" {
+		s += "; " + p.Err
 	}
-	if n == 0 {
-		return 0
+	return s
+}
+
+// statusColor returns the text colour for rows that did not pass, or nil
+// to keep the theme's.
+func statusColor(s benchmarks.Status) *qt.QColor {
+	switch s {
+	case benchmarks.StatusFailed:
+		return qt.NewQColor3(235, 100, 100)
+	case benchmarks.StatusCanceled:
+		return qt.NewQColor3(230, 170, 80)
+	case benchmarks.StatusSkipped, benchmarks.StatusPending:
+		return qt.NewQColor3(140, 140, 140)
 	}
-	return math.Pow(prod, 1.0/float64(n))
+	return nil
 }
 
-func populateTab(t *tabWidgets, results []benchmarks.Result) {
+func populateTab(t *tabWidgets, results []benchmarks.Result, ref *scoring.RefSet, w scoring.Weights) {
+	t.results = results
 	t.table.SetRowCount(0)
+	t.hist.SetHistogram("", nil)
+	t.hist.SetVisible(true)
+	t.curve.SetVisible(false)
+	t.detail.SetVisible(false)
 
 	var bars []ui.Bar
-	var cpu, mem, stor, img []float64
 
 	for _, r := range results {
 		row := t.table.RowCount()
 		t.table.InsertRow(row)
 
-		score := scoring.Score(r)
+		score := ref.Score(r)
 
-		t.table.SetItem(row, 0, qt.NewQTableWidgetItem2(r.Name))
-		t.table.SetItem(row, 1, qt.NewQTableWidgetItem2(fmt.Sprintf("%d", r.Threads)))
-		t.table.SetItem(row, 2, qt.NewQTableWidgetItem2(fmt.Sprintf("%.2f", r.Duration.Seconds())))
-		t.table.SetItem(row, 3, qt.NewQTableWidgetItem2(r.ThroughputString()))
-		t.table.SetItem(row, 4, qt.NewQTableWidgetItem2(fmt.Sprintf("%.0f", score)))
-		t.table.SetItem(row, 5, qt.NewQTableWidgetItem2(r.Notes))
+		scoreText := fmt.Sprintf("%.0f", score)
+		if tw := w.Test(r.Name); tw != 1 && scoring.SectionOf(r.Name) != "" {
+			scoreText += fmt.Sprintf(" (×%g)", tw)
+		}
+		notes := r.Notes
+		if r.Status != benchmarks.StatusPassed {
+			scoreText = "—"
+			notes = r.Err
+		}
+		effText := "—"
+		if es := ref.EfficiencyScore(r); es > 0 {
+			effText = fmt.Sprintf("%.0f", es)
+		}
+		cells := []string{
+			r.Name, r.Status.String(), fmt.Sprintf("%d", r.Threads), fmt.Sprintf("%.2f", r.Duration.Seconds()),
+			r.ThroughputString(), scoreText, r.EfficiencyString(), effText,
+			r.PercentileString(50), r.PercentileString(99), r.PercentileString(99.9), notes,
+		}
+		for col, text := range cells {
+			item := qt.NewQTableWidgetItem2(text)
+			if c := statusColor(r.Status); c != nil {
+				item.SetForeground(qt.NewQBrush3(c))
+			}
+			switch {
+			case col == len(cells)-1 && r.Status != benchmarks.StatusPassed:
+				item.SetToolTip(r.Err)
+			case col == 2 && r.Placement != nil:
+				item.SetToolTip(placementString(r.Placement))
+			case col == 6 && r.Energy != nil:
+				item.SetToolTip(fmt.Sprintf("%s, %.1f J over %.1f s", r.EnergyPerUnitString(), r.Energy.Joules, r.Energy.Seconds))
+			}
+			t.table.SetItem(row, col, item)
+		}
 
 		bars = append(bars, ui.Bar{Label: shortName(r.Name), Value: score})
+	}
 
-		switch r.Name {
-		case "CPU SHA-256", "AES-CTR", "Zstd Compress", "Gzip Compress", "JSON Parse", "MatMul":
-			cpu = append(cpu, score)
-		case "Memory copy":
-			mem = append(mem, score)
-		case "Disk seq R/W":
-			stor = append(stor, score)
-		case "Gaussian Blur 1080p":
-			img = append(img, score)
+	// overall + section tiles, titled with their weights
+	sum := ref.Summarize(results, w)
+	overallText := fmt.Sprintf("Overall: %.0f  (%s, %s)", sum.Overall, ref.Name, w.Name)
+	if sum.Incomplete {
+		overallText = fmt.Sprintf("Overall: %.0f*  (%s, %s) — incomplete, %d test(s) did not pass, %d without a reference",
+			sum.Overall, ref.Name, w.Name, len(sum.NotPassed), len(sum.Unreferenced))
+	}
+	if sum.Efficiency > 0 {
+		overallText += fmt.Sprintf("   Efficiency: %.0f", sum.Efficiency)
+	}
+	t.overall.SetText(overallText)
+	for name, tile := range t.tiles {
+		tile.Box.SetTitle(fmt.Sprintf("%s ×%g", name, w.Section(name)))
+		val := fmt.Sprintf("%.0f", sum.Sections[name])
+		tip := ""
+		if sum.SectionIncomplete(name) {
+			val += "*"
+			tip = "incomplete: some tests in this section did not pass or have no reference"
 		}
+		tile.Value.SetText(val)
+		tile.Box.SetToolTip(tip)
 	}
 
-	// overall + section tiles
-	overall := scoring.Aggregate(results)
-	t.overall.SetText(fmt.Sprintf("Overall: %.0f", overall))
-
-	t.tileCPU.Value.SetText(fmt.Sprintf("%.0f", geo(cpu)))
-	t.tileMem.Value.SetText(fmt.Sprintf("%.0f", geo(mem)))
-	t.tileStor.Value.SetText(fmt.Sprintf("%.0f", geo(stor)))
-	t.tileImg.Value.SetText(fmt.Sprintf("%.0f", geo(img)))
-
 	// chart
 	t.chart.SetData(bars)
 
 	// export blob
 	b, _ := json.MarshalIndent(struct {
-		System   sysinfo.Info        `json:"system"`
-		Results  []benchmarks.Result `json:"results"`
-		Overall  float64             `json:"overall"`
-		Sections map[string]float64  `json:"sections"`
+		System    sysinfo.Info        `json:"system"`
+		Results   []benchmarks.Result `json:"results"`
+		Reference scoring.RefInfo     `json:"reference"`
+		scoring.Summary
 	}{
-		System:  sysinfo.Collect(),
-		Results: results,
-		Overall: overall,
-		Sections: map[string]float64{
-			"CPU": geo(cpu), "Memory": geo(mem), "Storage": geo(stor), "Image": geo(img),
-		},
+		System:    sysinfo.Collect(),
+		Results:   results,
+		Reference: ref.Info(),
+		Summary:   sum,
 	}, "", "  ")
 	t.lastJSON = b
 }